@@ -0,0 +1,420 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asemock provides a mock connector to test applications using
+// go-ase without an ASE instance.
+//
+// The statements an application is expected to execute are registered
+// with their results on a Mock:
+//
+//	mock, err := asemock.New()
+//	if err != nil {
+//		...
+//	}
+//	defer mock.Close()
+//
+//	mock.ExpectBegin()
+//	mock.Expect("update t set a = ? where b = ?").WithArgs(int64(1), "one").
+//		WillReturnResult(1)
+//	mock.ExpectCommit()
+//
+//	db := sql.OpenDB(mock)
+//	...
+//
+//	if err := mock.ExpectationsWereMet(); err != nil {
+//		t.Error(err)
+//	}
+//
+// The connections opened by a Mock are *ase.Conn connected to an
+// asetest.Server, hence applications can use the complete API of go-ase
+// (e.g. Conn.DirectExec, Conn.NewCursor, hooks and message handlers)
+// and receive the same errors as from ASE.
+package asemock
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/SAP/go-ase"
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// Messages of errors that go-ase handles, e.g. with ase.IsRetryable.
+var (
+	// Deadlock is sent to the victim of a deadlock, whose transaction
+	// has been rolled back.
+	Deadlock = asetest.Message{
+		Number:   1205,
+		Severity: 13,
+		Text:     "Your server command (family id #0, process id #1) encountered a deadlock situation. Please re-run your command.",
+	}
+
+	// LockTimeout is sent when a lock could not be acquired within
+	// the lock wait period, which aborts the transaction.
+	LockTimeout = asetest.Message{
+		Number:   12205,
+		Severity: 17,
+		Text:     "Could not acquire a lock within the specified wait period. SERVER level wait period=1800 seconds, spid=1, lock type=exclusive table, dbid=4, objid=1, pageno=0, rowno=0. Aborting the transaction.",
+	}
+)
+
+// Mock is a driver.Connector whose connections execute the expected
+// statements.
+type Mock struct {
+	server    *asetest.Server
+	connector *ase.Connector
+
+	closeOnce sync.Once
+	closeErr  error
+
+	lock sync.Mutex
+	// ordered reports whether the expectations must be met in the
+	// order they were registered.
+	ordered      bool
+	expectations []*Expectation
+	// inTransaction is the transaction state after the last expected
+	// statement.
+	inTransaction bool
+	// errs are the errors of unexpected requests.
+	errs []error
+}
+
+var _ driver.Connector = (*Mock)(nil)
+
+// New returns a Mock with a new connector.
+//
+// The options are applied to the connector, e.g. to set hooks or
+// a message handler.
+func New(opts ...ase.ConnectorOption) (*Mock, error) {
+	server, err := asetest.NewServer()
+	if err != nil {
+		return nil, fmt.Errorf("asemock: error starting server: %w", err)
+	}
+
+	mock := &Mock{server: server, ordered: true}
+	server.HandleFunc("", mock.handle)
+
+	info, err := ase.NewInfo()
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("asemock: error creating info: %w", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+
+	mock.connector, err = ase.NewConnectorWithOptions(context.Background(), info,
+		append([]ase.ConnectorOption{ase.WithoutValidation()}, opts...)...)
+	if err != nil {
+		server.Close()
+		return nil, fmt.Errorf("asemock: error creating connector: %w", err)
+	}
+
+	return mock, nil
+}
+
+// Connector returns the connector of the mock, e.g. to change its
+// Info before connections are opened.
+func (mock *Mock) Connector() *ase.Connector {
+	return mock.connector
+}
+
+// Connect implements the driver.Connector interface.
+//
+// The returned connection is an *ase.Conn.
+func (mock *Mock) Connect(ctx context.Context) (driver.Conn, error) {
+	return mock.connector.Connect(ctx)
+}
+
+// Driver implements the driver.Connector interface.
+func (mock *Mock) Driver() driver.Driver {
+	return mock.connector.Driver()
+}
+
+// Close closes the connections of the mock.
+//
+// Close is called by sql.DB.Close if the mock is passed to sql.OpenDB.
+// Subsequent calls return the error of the first call.
+func (mock *Mock) Close() error {
+	mock.closeOnce.Do(func() {
+		if err := mock.server.Close(); err != nil {
+			mock.closeErr = fmt.Errorf("asemock: %w", err)
+		}
+	})
+
+	return mock.closeErr
+}
+
+// MatchExpectationsInOrder sets whether the expectations must be met in
+// the order they were registered, which is the default.
+func (mock *Mock) MatchExpectationsInOrder(ordered bool) {
+	mock.lock.Lock()
+	defer mock.lock.Unlock()
+
+	mock.ordered = ordered
+}
+
+// Expect registers the expectation that query is executed as language
+// command, dynamic SQL statement or cursor.
+//
+// Without further calls the statement succeeds without results.
+func (mock *Mock) Expect(query string) *Expectation {
+	return mock.expect(query, query, func(req asetest.Request) bool {
+		return req.Query == query
+	})
+}
+
+// ExpectCursor registers the expectation that a cursor is opened with
+// query, e.g. with ase.Conn.NewCursor.
+func (mock *Mock) ExpectCursor(query string) *Expectation {
+	return mock.expect("cursor "+query, query, func(req asetest.Request) bool {
+		return req.Kind == asetest.Cursor && req.Query == query
+	})
+}
+
+// ExpectBegin registers the expectation that a transaction is begun.
+func (mock *Mock) ExpectBegin() *Expectation {
+	return mock.expectTransaction("begin transaction", true)
+}
+
+// ExpectCommit registers the expectation that a transaction is
+// committed.
+func (mock *Mock) ExpectCommit() *Expectation {
+	return mock.expectTransaction("commit", false)
+}
+
+// ExpectRollback registers the expectation that a transaction is rolled
+// back.
+func (mock *Mock) ExpectRollback() *Expectation {
+	return mock.expectTransaction("rollback", false)
+}
+
+// expectTransaction registers the expectation that the transaction
+// command is executed, which sets the transaction state of the mock
+// to inTransaction.
+func (mock *Mock) expectTransaction(command string, inTransaction bool) *Expectation {
+	expectation := mock.expect(command, command, func(req asetest.Request) bool {
+		return req.Kind == asetest.Language && strings.HasPrefix(req.Query, command)
+	})
+	expectation.inTransaction = &inTransaction
+	return expectation
+}
+
+// expect registers an expectation for query matching requests with
+// match.
+func (mock *Mock) expect(description, query string, match func(asetest.Request) bool) *Expectation {
+	mock.lock.Lock()
+	defer mock.lock.Unlock()
+
+	expectation := &Expectation{
+		mock:        mock,
+		description: description,
+		query:       query,
+		match:       match,
+		results:     []asetest.Result{{}},
+	}
+	mock.expectations = append(mock.expectations, expectation)
+
+	return expectation
+}
+
+// ExpectationsWereMet returns an error if a request was not expected or
+// an expectation was not met.
+func (mock *Mock) ExpectationsWereMet() error {
+	mock.lock.Lock()
+	defer mock.lock.Unlock()
+
+	if len(mock.errs) > 0 {
+		return mock.errs[0]
+	}
+
+	for _, expectation := range mock.expectations {
+		if expectation.err != nil {
+			return expectation.err
+		}
+
+		if !expectation.met {
+			return fmt.Errorf("asemock: expectation was not met: %s", expectation)
+		}
+	}
+
+	return nil
+}
+
+// handle returns the results of the expectation matching req.
+func (mock *Mock) handle(req asetest.Request) []asetest.Result {
+	mock.lock.Lock()
+	defer mock.lock.Unlock()
+
+	for _, expectation := range mock.expectations {
+		if expectation.met {
+			continue
+		}
+
+		if expectation.matches(req) {
+			expectation.met = true
+			return mock.results(expectation)
+		}
+
+		if mock.ordered {
+			break
+		}
+	}
+
+	err := fmt.Errorf("asemock: unexpected %s request: %s %v", req.Kind, req.Query, req.Args)
+	mock.errs = append(mock.errs, err)
+
+	return []asetest.Result{{
+		Error:         &asetest.Message{Severity: 16, Text: err.Error()},
+		InTransaction: mock.inTransaction,
+	}}
+}
+
+// results returns the results of expectation with the transaction state
+// of the mock.
+func (mock *Mock) results(expectation *Expectation) []asetest.Result {
+	if expectation.inTransaction != nil {
+		mock.inTransaction = *expectation.inTransaction
+	}
+
+	results := make([]asetest.Result, len(expectation.results))
+	for i, result := range expectation.results {
+		// ASE rolls back the transaction of a deadlock victim or on
+		// a lock timeout.
+		if result.Error != nil && (result.Error.Number == Deadlock.Number || result.Error.Number == LockTimeout.Number) {
+			mock.inTransaction = false
+		}
+
+		result.InTransaction = mock.inTransaction
+		results[i] = result
+	}
+
+	return results
+}
+
+// Expectation is a statement the application is expected to execute.
+type Expectation struct {
+	mock        *Mock
+	description string
+	query       string
+	match       func(asetest.Request) bool
+	// args are the expected arguments, nil if the arguments are not
+	// checked.
+	args    []interface{}
+	results []asetest.Result
+	// inTransaction is the transaction state after the statement if it
+	// changes the state.
+	inTransaction *bool
+
+	met bool
+	// err is set if the expectation is invalid.
+	err error
+}
+
+// String returns the description of the expectation.
+func (expectation *Expectation) String() string {
+	if expectation.args != nil {
+		return fmt.Sprintf("%s %v", expectation.description, expectation.args)
+	}
+	return expectation.description
+}
+
+// matches reports whether req meets the expectation.
+func (expectation *Expectation) matches(req asetest.Request) bool {
+	if !expectation.match(req) {
+		return false
+	}
+
+	if expectation.args == nil {
+		return true
+	}
+
+	if len(req.Args) != len(expectation.args) {
+		return false
+	}
+
+	for i, arg := range expectation.args {
+		if !reflect.DeepEqual(normalize(arg), req.Args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalize returns value in the representation the server receives.
+func normalize(value interface{}) interface{} {
+	if i, ok := value.(int); ok {
+		return int64(i)
+	}
+	return value
+}
+
+// WithArgs sets the expected arguments of the statement.
+//
+// The parameter types the server reports when the statement is prepared
+// are derived from the arguments, see asetest.Column for the supported
+// types. Statements with parameters cannot be prepared without expected
+// arguments.
+func (expectation *Expectation) WithArgs(args ...interface{}) *Expectation {
+	types := make([]asetypes.DataType, len(args))
+	for i, arg := range args {
+		dataType, err := asetest.TypeOf(arg)
+		if err != nil {
+			expectation.setErr(fmt.Errorf("asemock: argument %d of %s: %w", i+1, expectation.description, err))
+			return expectation
+		}
+		types[i] = dataType
+	}
+
+	expectation.mock.lock.Lock()
+	expectation.args = append([]interface{}{}, args...)
+	expectation.mock.lock.Unlock()
+
+	if len(types) > 0 {
+		expectation.mock.server.Params(expectation.query, types...)
+	}
+
+	return expectation
+}
+
+// WillReturnResult sets the number of rows affected by the statement.
+func (expectation *Expectation) WillReturnResult(rowsAffected int) *Expectation {
+	return expectation.WillReturn(asetest.Result{RowsAffected: rowsAffected})
+}
+
+// WillReturnRows sets the result set returned by the statement.
+func (expectation *Expectation) WillReturnRows(columns []asetest.Column, rows ...[]interface{}) *Expectation {
+	return expectation.WillReturn(asetest.Result{Columns: columns, Rows: rows})
+}
+
+// WillReturnError fails the statement with the message, e.g. Deadlock.
+//
+// The application receives a *tds.EEDError as from ASE.
+func (expectation *Expectation) WillReturnError(msg asetest.Message) *Expectation {
+	return expectation.WillReturn(asetest.Result{Error: &msg})
+}
+
+// WillReturn sets the results of the statement, e.g. multiple result
+// sets, messages or a return status.
+func (expectation *Expectation) WillReturn(results ...asetest.Result) *Expectation {
+	expectation.mock.lock.Lock()
+	defer expectation.mock.lock.Unlock()
+
+	expectation.results = results
+	return expectation
+}
+
+// setErr records that the expectation is invalid.
+func (expectation *Expectation) setErr(err error) {
+	expectation.mock.lock.Lock()
+	defer expectation.mock.lock.Unlock()
+
+	if expectation.err == nil {
+		expectation.err = err
+	}
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asemock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-ase"
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// newTestMock returns a mock that is closed when the test finishes.
+func newTestMock(t *testing.T, opts ...ase.ConnectorOption) *Mock {
+	t.Helper()
+
+	mock, err := New(opts...)
+	if err != nil {
+		t.Fatalf("error creating mock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.Close(); err != nil {
+			t.Errorf("error closing mock: %v", err)
+		}
+	})
+
+	return mock
+}
+
+// conn returns a connection of the mock, which is closed when the test
+// finishes.
+func conn(t *testing.T, mock *Mock) *ase.Conn {
+	t.Helper()
+
+	conn, err := mock.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn.(*ase.Conn)
+}
+
+func TestMockTransaction(t *testing.T) {
+	mock := newTestMock(t)
+	mock.ExpectBegin()
+	mock.Expect("update t set a = ? where b = ?").WithArgs(1, "one").WillReturnResult(2)
+	mock.ExpectCommit()
+
+	db := sql.OpenDB(mock)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	result, err := tx.Exec("update t set a = ? where b = ?", 1, "one")
+	if err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected != 2 {
+		t.Errorf("expected 2 affected rows, got %d: %v", affected, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMockQuery(t *testing.T) {
+	mock := newTestMock(t)
+	mock.Expect("select a, b from t").WillReturnRows(
+		[]asetest.Column{{Name: "a"}, {Name: "b"}},
+		[]interface{}{int64(1), "one"},
+		[]interface{}{int64(2), "two"},
+	)
+
+	db := sql.OpenDB(mock)
+	defer db.Close()
+
+	rows, err := db.Query("select a, b from t")
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	defer rows.Close()
+
+	var received []string
+	for rows.Next() {
+		var a int64
+		var b string
+		if err := rows.Scan(&a, &b); err != nil {
+			t.Fatalf("error scanning row: %v", err)
+		}
+		received = append(received, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatalf("error reading rows: %v", err)
+	}
+
+	if !reflect.DeepEqual(received, []string{"one", "two"}) {
+		t.Errorf("unexpected rows: %v", received)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMockDeadlock(t *testing.T) {
+	mock := newTestMock(t)
+	mock.ExpectBegin()
+	mock.Expect("update t set a = 1").WillReturnError(Deadlock)
+	mock.ExpectRollback()
+
+	c := conn(t, mock)
+
+	tx, err := c.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	_, err = c.ExecContext(context.Background(), "update t set a = 1", nil)
+
+	var eedError *tds.EEDError
+	if !errors.As(err, &eedError) {
+		t.Fatalf("expected EEDError, got %v", err)
+	}
+
+	if !ase.IsRetryable(err) {
+		t.Errorf("expected deadlock to be retryable: %v", err)
+	}
+
+	if c.InTransaction() {
+		t.Errorf("expected transaction of deadlock victim to be rolled back")
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("error rolling back transaction: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMockConn(t *testing.T) {
+	var lock sync.Mutex
+	var hooked []string
+	mock := newTestMock(t, ase.WithEEDHooks(func(eed tds.EEDPackage) {
+		lock.Lock()
+		defer lock.Unlock()
+		hooked = append(hooked, eed.Msg)
+	}))
+
+	mock.Expect("exec p").WillReturn(
+		asetest.Result{Messages: []asetest.Message{{Text: "a"}}},
+		asetest.Result{ReturnStatus: 1},
+	)
+	mock.ExpectCursor("select a from t where a > ?").WithArgs(int64(1)).WillReturnRows(
+		[]asetest.Column{{Name: "a"}},
+		[]interface{}{int64(2)},
+	)
+
+	c := conn(t, mock)
+
+	_, _, err := c.DirectExec(context.Background(), "exec p")
+	if err == nil || !strings.Contains(err.Error(), "return status 1") {
+		t.Errorf("expected error for return status, got %v", err)
+	}
+
+	lock.Lock()
+	if !reflect.DeepEqual(hooked, []string{"a"}) {
+		t.Errorf("unexpected messages passed to hook: %q", hooked)
+	}
+	lock.Unlock()
+
+	cursor, err := c.NewCursor(context.Background(), "select a from t where a > ?", int64(1))
+	if err != nil {
+		t.Fatalf("error creating cursor: %v", err)
+	}
+	defer cursor.Close(context.Background())
+
+	rows, err := cursor.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("error fetching cursor: %v", err)
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, 1)
+	if err := rows.Next(values); err != nil {
+		t.Fatalf("error reading row: %v", err)
+	}
+
+	if values[0] != int64(2) {
+		t.Errorf("expected 2, got %v", values[0])
+	}
+
+	if err := rows.Next(values); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMockUnexpected(t *testing.T) {
+	mock := newTestMock(t)
+	mock.Expect("delete from t")
+	mock.Expect("update t set a = ?").WithArgs(int64(1))
+
+	c := conn(t, mock)
+
+	// The expectations are met in order.
+	if _, err := c.ExecContext(context.Background(), "update t set a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err == nil {
+		t.Errorf("expected error executing statement out of order")
+	}
+
+	err := mock.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "unexpected dynamic request: update t set a = ? [1]") {
+		t.Errorf("expected error for unexpected request, got %v", err)
+	}
+}
+
+func TestMockUnordered(t *testing.T) {
+	mock := newTestMock(t)
+	mock.MatchExpectationsInOrder(false)
+	mock.Expect("delete from t")
+	mock.Expect("update t set a = ?").WithArgs(int64(1))
+
+	c := conn(t, mock)
+
+	if _, err := c.ExecContext(context.Background(), "update t set a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	// Arguments must match.
+	if _, err := c.ExecContext(context.Background(), "update t set a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(2)}}); err == nil {
+		t.Errorf("expected error executing statement with unexpected arguments")
+	}
+
+	mock.errs = nil
+	err := mock.ExpectationsWereMet()
+	if err == nil || err.Error() != "asemock: expectation was not met: delete from t" {
+		t.Errorf("expected error for unmet expectation, got %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asetest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// loginRecordSize is the size of the tokenless login record sent by
+// the client at the start of the login message.
+const loginRecordSize = 568
+
+// packetSize is the size of the packets written by the server.
+const packetSize = 512
+
+// loginUsername returns the username in the login record at the start
+// of data. The username follows the host name, both are padded to
+// tds.TDS_MAXNAME and followed by their length.
+func loginUsername(data []byte) string {
+	offset := tds.TDS_MAXNAME + 1
+	length := int(data[offset+tds.TDS_MAXNAME])
+	if length > tds.TDS_MAXNAME {
+		length = tds.TDS_MAXNAME
+	}
+	return string(data[offset : offset+length])
+}
+
+// readMessage reads all packets of a message from conn and parses the
+// packages.
+func readMessage(conn net.Conn) ([]tds.Package, error) {
+	data, err := readData(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePackages(data)
+}
+
+// readData reads all packets of a message from conn and returns their
+// data.
+func readData(conn net.Conn) ([]byte, error) {
+	var data []byte
+	for {
+		packet := &tds.Packet{}
+		if _, err := packet.ReadFrom(context.Background(), conn, time.Hour); err != nil {
+			return nil, err
+		}
+
+		data = append(data, packet.Data...)
+		if packet.Header.Status&tds.TDS_BUFSTAT_EOM == tds.TDS_BUFSTAT_EOM {
+			break
+		}
+	}
+
+	return data, nil
+}
+
+// parsePackages parses the packages in data.
+func parsePackages(data []byte) ([]tds.Package, error) {
+	queue := tds.NewPacketQueue(func() int { return len(data) + tds.PacketHeaderSize })
+	queue.AddPacket(&tds.Packet{
+		Header: tds.PacketHeader{Status: tds.TDS_BUFSTAT_EOM},
+		Data:   data,
+	})
+
+	var pkgs []tds.Package
+	var last tds.Package
+	for !queue.AllPacketsConsumed() {
+		token, err := queue.Byte()
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, err := lookupPackage(tds.Token(token))
+		if err != nil {
+			return nil, err
+		}
+
+		// Tokenless packages consume the remaining data, which the
+		// queue pads with zeroes.
+		if tokenless, ok := pkg.(*tds.TokenlessPackage); ok {
+			tokenless.Data.WriteByte(token)
+			indexPacket, indexData := queue.Position()
+			if indexPacket == 0 {
+				rest, _ := queue.Bytes(len(data) - indexData)
+				tokenless.Data.Write(rest)
+			}
+			pkgs = append(pkgs, tokenless)
+			break
+		}
+
+		if acceptor, ok := pkg.(tds.LastPkgAcceptor); ok {
+			if err := acceptor.LastPkg(last); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := pkg.ReadFrom(queue); err != nil {
+			return nil, fmt.Errorf("error parsing %T: %w", pkg, err)
+		}
+
+		pkgs = append(pkgs, pkg)
+		last = pkg
+	}
+
+	return pkgs, nil
+}
+
+// lookupPackage returns the package for token.
+//
+// go-dblib does not look up TDS_CURCLOSE, which only clients send.
+func lookupPackage(token tds.Token) (tds.Package, error) {
+	if token == tds.TDS_CURCLOSE {
+		return &tds.CurClosePackage{}, nil
+	}
+
+	return tds.LookupPackage(token)
+}
+
+// writeMessage writes the packages as a response message to conn.
+func writeMessage(conn net.Conn, pkgs ...tds.Package) error {
+	queue := tds.NewPacketQueue(func() int { return packetSize })
+	for _, pkg := range pkgs {
+		if err := pkg.WriteTo(queue); err != nil {
+			return fmt.Errorf("error writing %T: %w", pkg, err)
+		}
+	}
+
+	indexPacket, indexData := queue.Position()
+	length := indexPacket*(packetSize-tds.PacketHeaderSize) + indexData
+
+	queue.SetPosition(0, 0)
+	data, err := queue.Bytes(length)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n := len(data)
+		if n > packetSize-tds.PacketHeaderSize {
+			n = packetSize - tds.PacketHeaderSize
+		}
+
+		packet := &tds.Packet{
+			Header: tds.PacketHeader{
+				MsgType: tds.TDS_BUF_RESPONSE,
+				Length:  uint16(tds.PacketHeaderSize + n),
+			},
+			Data: data[:n],
+		}
+		data = data[n:]
+
+		if len(data) == 0 {
+			packet.Header.Status = tds.TDS_BUFSTAT_EOM
+		}
+
+		if _, err := packet.WriteTo(conn); err != nil {
+			return err
+		}
+
+		if len(data) == 0 {
+			return nil
+		}
+	}
+}
+
+// params returns a TDS_PARAMFMT and TDS_PARAMS package with the passed
+// values, see Column for the supported types.
+func params(values ...interface{}) (*tds.ParamFmtPackage, *tds.ParamsPackage, error) {
+	fmts := make([]tds.FieldFmt, len(values))
+	data := make([]tds.FieldData, len(values))
+
+	for i, value := range values {
+		dataType, err := TypeOf(value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fmts[i], data[i], err = tds.LookupFieldFmtData(dataType)
+		if err != nil {
+			return nil, nil, err
+		}
+		data[i].SetValue(convert(value))
+	}
+
+	paramFmt := tds.NewParamFmtPackage(false, fmts...)
+	paramsPkg := tds.NewParamsPackage(data...)
+	if err := paramsPkg.LastPkg(paramFmt); err != nil {
+		return nil, nil, err
+	}
+
+	return paramFmt, paramsPkg, nil
+}
+
+// paramFmt returns a TDS_PARAMFMT package for parameters of the passed
+// types.
+func paramFmt(types []asetypes.DataType) (*tds.ParamFmtPackage, error) {
+	fmts := make([]tds.FieldFmt, len(types))
+	for i, dataType := range types {
+		var err error
+		if fmts[i], err = tds.LookupFieldFmt(dataType); err != nil {
+			return nil, err
+		}
+	}
+
+	return tds.NewParamFmtPackage(false, fmts...), nil
+}
+
+// eedPackage is a TDS_EED package.
+//
+// tds.EEDPackage.WriteTo writes an invalid length.
+type eedPackage struct {
+	tds.EEDPackage
+}
+
+// WriteTo implements the tds.Package interface.
+func (pkg *eedPackage) WriteTo(ch tds.BytesChannel) error {
+	// message number, state, class, SQL state length, status, tran
+	// state, message length, server name length, proc name length,
+	// line number
+	length := 4 + 1 + 1 + 1 + len(pkg.SQLState) + 1 + 2 + 2 + len(pkg.Msg) +
+		1 + len(pkg.ServerName) + 1 + len(pkg.ProcName) + 2
+
+	if err := ch.WriteByte(byte(tds.TDS_EED)); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint16(uint16(length)); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint32(pkg.MsgNumber); err != nil {
+		return err
+	}
+
+	if err := ch.WriteBytes([]byte{pkg.State, pkg.Class, byte(len(pkg.SQLState))}); err != nil {
+		return err
+	}
+
+	if err := ch.WriteBytes(append(pkg.SQLState, byte(pkg.Status))); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint16(pkg.TranState); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint16(uint16(len(pkg.Msg))); err != nil {
+		return err
+	}
+
+	if err := ch.WriteString(pkg.Msg); err != nil {
+		return err
+	}
+
+	for _, str := range []string{pkg.ServerName, pkg.ProcName} {
+		if err := ch.WriteUint8(uint8(len(str))); err != nil {
+			return err
+		}
+
+		if err := ch.WriteString(str); err != nil {
+			return err
+		}
+	}
+
+	return ch.WriteUint16(pkg.LineNr)
+}
+
+// envChangePackage is a TDS_ENVCHANGE package with a single change.
+//
+// The members of tds.EnvChangePackage cannot be set.
+type envChangePackage struct {
+	tds.EnvChangePackage
+	typ                tds.EnvChangeType
+	oldValue, newValue string
+}
+
+// WriteTo implements the tds.Package interface.
+func (pkg *envChangePackage) WriteTo(ch tds.BytesChannel) error {
+	if err := ch.WriteByte(byte(tds.TDS_ENVCHANGE)); err != nil {
+		return err
+	}
+
+	// type, new value length, new value, old value length, old value
+	if err := ch.WriteUint16(uint16(3 + len(pkg.newValue) + len(pkg.oldValue))); err != nil {
+		return err
+	}
+
+	if err := ch.WriteBytes([]byte{byte(pkg.typ), byte(len(pkg.newValue))}); err != nil {
+		return err
+	}
+
+	if err := ch.WriteString(pkg.newValue); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint8(uint8(len(pkg.oldValue))); err != nil {
+		return err
+	}
+
+	return ch.WriteString(pkg.oldValue)
+}
+
+// returnStatusPackage is a TDS_RETURNSTATUS package.
+//
+// tds.ReturnStatusPackage.WriteTo does not write the token.
+type returnStatusPackage struct {
+	tds.ReturnStatusPackage
+}
+
+// WriteTo implements the tds.Package interface.
+func (pkg *returnStatusPackage) WriteTo(ch tds.BytesChannel) error {
+	if err := ch.WriteByte(byte(tds.TDS_RETURNSTATUS)); err != nil {
+		return err
+	}
+
+	return ch.WriteInt32(pkg.ReturnValue)
+}
+
+// rowFmtPackage is a TDS_ROWFMT2 package, which go-dblib can only read.
+type rowFmtPackage struct {
+	tds.RowFmtPackage
+}
+
+// WriteTo implements the tds.Package interface.
+func (pkg *rowFmtPackage) WriteTo(ch tds.BytesChannel) error {
+	// column count, per column: label, catalogue, schema and table
+	// length, name length, name, status, usertype, token, format,
+	// locale length, locale
+	length := 2
+	for _, field := range pkg.Fmts {
+		length += 4 + 1 + len(field.Name()) + 4 + 4 + 1 + field.FormatByteLength() + 1 + len(field.LocaleInfo())
+	}
+
+	if err := ch.WriteByte(byte(tds.TDS_ROWFMT2)); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint32(uint32(length)); err != nil {
+		return err
+	}
+
+	if err := ch.WriteUint16(uint16(len(pkg.Fmts))); err != nil {
+		return err
+	}
+
+	paramFmt := tds.NewParamFmtPackage(true)
+	for _, field := range pkg.Fmts {
+		if err := ch.WriteBytes(make([]byte, 4)); err != nil {
+			return err
+		}
+
+		if _, err := paramFmt.WriteToField(ch, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asetest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Result is the response to a single statement.
+//
+// A request may be answered with multiple results, e.g. for batches of
+// statements or stored procedures. The done statuses are derived from
+// the results: all but the last result are sent with TDS_DONE_MORE,
+// results with rows or affected rows with TDS_DONE_COUNT.
+type Result struct {
+	// Columns are the columns of the result set. Results without
+	// columns do not return a result set.
+	Columns []Column
+	Rows    [][]interface{}
+	// RowsAffected is the number of rows affected by a statement
+	// without a result set.
+	RowsAffected int
+
+	// Messages are sent before the result set, e.g. the output of
+	// print.
+	Messages []Message
+	// EnvChanges are sent before the messages.
+	EnvChanges []EnvChange
+
+	// Error fails the statement with the message. Columns and rows of
+	// a failed result are not sent.
+	Error *Message
+
+	// ReturnStatus is sent after the result set if it is not zero, like
+	// the return status of a stored procedure.
+	ReturnStatus int32
+
+	// InTransaction reports that the connection is in a transaction
+	// after the statement.
+	InTransaction bool
+}
+
+// Column is a column of a result set.
+//
+// If Type is not set it is derived from the first non-nil value of the
+// column: int32 is sent as INT4, int and int64 as INT8, float64 as
+// FLT8, bool as BIT, string as VARCHAR, []byte as LONGBINARY and
+// time.Time as BIGDATETIMEN.
+type Column struct {
+	Name string
+	Type asetypes.DataType
+}
+
+// Message is a message sent as TDS_EED.
+type Message struct {
+	Number   uint32
+	Severity uint8
+	State    uint8
+	Text     string
+	Proc     string
+	Line     uint16
+}
+
+// EnvChange is a change of the environment of the connection, e.g. of
+// the database with tds.TDS_ENV_DB.
+type EnvChange struct {
+	Type     tds.EnvChangeType
+	Old, New string
+}
+
+// serverName is the name of the server in messages.
+const serverName = "asetest"
+
+// eed returns the message as package.
+func (msg Message) eed() *eedPackage {
+	return &eedPackage{tds.EEDPackage{
+		MsgNumber:  msg.Number,
+		State:      msg.State,
+		Class:      msg.Severity,
+		Msg:        msg.Text,
+		ServerName: serverName,
+		ProcName:   msg.Proc,
+		LineNr:     msg.Line,
+	}}
+}
+
+// prelude returns the env changes and messages of the result.
+func (result Result) prelude() []tds.Package {
+	var pkgs []tds.Package
+	for _, change := range result.EnvChanges {
+		pkgs = append(pkgs, &envChangePackage{typ: change.Type, oldValue: change.Old, newValue: change.New})
+	}
+
+	for _, msg := range result.Messages {
+		pkgs = append(pkgs, msg.eed())
+	}
+
+	return pkgs
+}
+
+// rowFmt returns the format of the result set.
+func (result Result) rowFmt() (*rowFmtPackage, error) {
+	fmts := make([]tds.FieldFmt, len(result.Columns))
+	for i, column := range result.Columns {
+		dataType := column.Type
+		if dataType == 0 {
+			for _, row := range result.Rows {
+				if i < len(row) && row[i] != nil {
+					var err error
+					if dataType, err = TypeOf(row[i]); err != nil {
+						return nil, fmt.Errorf("column %q: %w", column.Name, err)
+					}
+					break
+				}
+			}
+		}
+		if dataType == 0 {
+			return nil, fmt.Errorf("column %q: type cannot be derived without values", column.Name)
+		}
+
+		fieldFmt, err := tds.LookupFieldFmt(dataType)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", column.Name, err)
+		}
+		fieldFmt.SetName(column.Name)
+		fmts[i] = fieldFmt
+	}
+
+	rowFmt := &rowFmtPackage{}
+	rowFmt.Fmts = fmts
+	return rowFmt, nil
+}
+
+// rows returns the passed rows as packages with the format rowFmt.
+func rows(rowFmt *rowFmtPackage, values [][]interface{}) ([]tds.Package, error) {
+	pkgs := make([]tds.Package, len(values))
+	for i, row := range values {
+		if len(row) != len(rowFmt.Fmts) {
+			return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(rowFmt.Fmts))
+		}
+
+		pkg := &tds.RowPackage{}
+		pkg.DataFields = make([]tds.FieldData, len(row))
+		for j, value := range row {
+			data, err := tds.LookupFieldData(rowFmt.Fmts[j])
+			if err != nil {
+				return nil, err
+			}
+			data.SetValue(convert(value))
+			pkg.DataFields[j] = data
+		}
+
+		if err := pkg.LastPkg(&rowFmt.RowFmtPackage); err != nil {
+			return nil, err
+		}
+		pkgs[i] = pkg
+	}
+
+	return pkgs, nil
+}
+
+// TypeOf returns the data type values of the type of value are sent
+// as, see Column for the supported types.
+func TypeOf(value interface{}) (asetypes.DataType, error) {
+	switch value.(type) {
+	case int32:
+		return asetypes.INT4, nil
+	case int, int64:
+		return asetypes.INT8, nil
+	case float64:
+		return asetypes.FLT8, nil
+	case bool:
+		return asetypes.BIT, nil
+	case string:
+		return asetypes.VARCHAR, nil
+	case []byte:
+		return asetypes.LONGBINARY, nil
+	case time.Time:
+		return asetypes.BIGDATETIMEN, nil
+	}
+	return 0, fmt.Errorf("unsupported value type %T", value)
+}
+
+// convert returns value in the representation go-dblib writes.
+func convert(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case int:
+		return int64(typed)
+	case string:
+		return []byte(typed)
+	}
+	return value
+}
+
+// response returns the packages of the results.
+//
+// inTransaction reports the transaction state of the connection after
+// the results.
+func response(results []Result, inTransaction bool) ([]tds.Package, bool, error) {
+	if len(results) == 0 {
+		return []tds.Package{done(tds.TDS_DONE_FINAL, 0, inTransaction)}, inTransaction, nil
+	}
+
+	var pkgs []tds.Package
+	for i, result := range results {
+		pkgs = append(pkgs, result.prelude()...)
+		inTransaction = result.InTransaction
+
+		status := tds.TDS_DONE_FINAL
+		if i < len(results)-1 {
+			status = tds.TDS_DONE_MORE
+		}
+
+		if result.Error != nil {
+			pkgs = append(pkgs, result.Error.eed(), done(status|tds.TDS_DONE_ERROR, 0, inTransaction))
+			continue
+		}
+
+		count := result.RowsAffected
+		if len(result.Columns) > 0 {
+			rowFmt, err := result.rowFmt()
+			if err != nil {
+				return nil, inTransaction, err
+			}
+
+			rowPkgs, err := rows(rowFmt, result.Rows)
+			if err != nil {
+				return nil, inTransaction, err
+			}
+
+			pkgs = append(append(pkgs, rowFmt), rowPkgs...)
+			count = len(result.Rows)
+		}
+
+		if result.ReturnStatus != 0 {
+			pkgs = append(pkgs, &returnStatusPackage{tds.ReturnStatusPackage{ReturnValue: result.ReturnStatus}})
+		}
+
+		if count > 0 {
+			status |= tds.TDS_DONE_COUNT
+		}
+		pkgs = append(pkgs, done(status, count, inTransaction))
+	}
+
+	return pkgs, inTransaction, nil
+}
+
+// done returns a done package with the status and count.
+func done(status tds.DoneState, count int, inTransaction bool) *tds.DonePackage {
+	if inTransaction {
+		status |= tds.TDS_DONE_INXACT
+	}
+
+	return &tds.DonePackage{Status: status, Count: int32(count)}
+}
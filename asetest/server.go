@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asetest provides a scripted in-process TDS server to unit
+// test code using go-ase without an ASE instance.
+//
+// The server listens on a loopback address and answers language
+// commands, dynamic SQL statements, cursors and option commands with
+// the Results registered for their query:
+//
+//	server, err := asetest.NewServer()
+//	if err != nil {
+//		...
+//	}
+//	defer server.Close()
+//
+//	server.Handle("select a, b from t", asetest.Result{
+//		Columns: []asetest.Column{{Name: "a"}, {Name: "b"}},
+//		Rows:    [][]interface{}{{int64(1), "one"}},
+//	})
+//
+//	info, _ := ase.NewInfo()
+//	info.Host, info.Port = server.Host(), server.Port()
+//
+// Every login succeeds unless a LoginFunc is registered with
+// HandleLogin, e.g. to reject outdated passwords.
+//
+// The server only implements the parts of TDS that go-ase uses and does
+// not interpret the queries.
+package asetest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+var (
+	serverKey     *rsa.PrivateKey
+	serverKeyErr  error
+	serverKeyOnce sync.Once
+)
+
+// RequestKind is the kind of a request received by the server.
+type RequestKind int
+
+const (
+	// Language is a language command.
+	Language RequestKind = iota
+	// Dynamic is the execution of a prepared statement.
+	Dynamic
+	// Cursor is the opening of a cursor.
+	Cursor
+	// Option is an option command. The query of the request is the
+	// command and the option, e.g. "TDS_OPT_SET TDS_OPT_ISOLATION",
+	// and its argument is the argument of the option as []byte.
+	Option
+)
+
+// String implements the fmt.Stringer interface.
+func (kind RequestKind) String() string {
+	switch kind {
+	case Language:
+		return "language"
+	case Dynamic:
+		return "dynamic"
+	case Cursor:
+		return "cursor"
+	case Option:
+		return "option"
+	}
+	return fmt.Sprintf("RequestKind(%d)", int(kind))
+}
+
+// Request is a query received by the server.
+type Request struct {
+	Kind  RequestKind
+	Query string
+	Args  []interface{}
+}
+
+// HandlerFunc returns the results of a request.
+type HandlerFunc func(req Request) []Result
+
+// Login is a login received by the server.
+type Login struct {
+	Username string
+	Password string
+}
+
+// LoginFunc returns the messages sent in response to a login.
+//
+// The login is rejected if one of the messages has a severity above
+// 10, e.g. message 4002 for invalid credentials. Otherwise the messages
+// are sent with the acknowledgement of the login, e.g. message 4022 for
+// an expired password.
+type LoginFunc func(login Login) []Message
+
+// Server is a scripted TDS server.
+type Server struct {
+	listener net.Listener
+
+	lock     sync.Mutex
+	handlers map[string]HandlerFunc
+	loginFn  LoginFunc
+	params   map[string][]asetypes.DataType
+	requests []Request
+	conns    map[net.Conn]struct{}
+	closed   bool
+	errs     []error
+
+	wg sync.WaitGroup
+}
+
+// NewServer starts a server listening on a loopback address.
+func NewServer() (*Server, error) {
+	serverKeyOnce.Do(func() {
+		serverKey, serverKeyErr = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	if serverKeyErr != nil {
+		return nil, fmt.Errorf("asetest: error generating server key: %w", serverKeyErr)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("asetest: error listening on loopback: %w", err)
+	}
+
+	s := &Server{
+		listener: listener,
+		handlers: map[string]HandlerFunc{},
+		params:   map[string][]asetypes.DataType{},
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return s, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Host returns the host the server listens on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Addr())
+	return host
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.Addr())
+	return port
+}
+
+// Close stops the server and closes all connections. It returns the
+// first error that occurred while serving a connection.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+
+	if len(s.errs) > 0 {
+		return s.errs[0]
+	}
+
+	if err != nil {
+		return fmt.Errorf("asetest: error closing listener: %w", err)
+	}
+
+	return nil
+}
+
+// Handle registers the results returned for query. Every request with
+// the query receives the same results.
+func (s *Server) Handle(query string, results ...Result) {
+	s.HandleFunc(query, func(Request) []Result {
+		return results
+	})
+}
+
+// HandleFunc registers fn to return the results for query.
+//
+// The handler registered for the empty query receives the requests for
+// queries without a handler. Without it these requests receive an
+// error.
+//
+// Option commands without a handler are acknowledged and not passed to
+// the handler of the empty query. A handler registered for an option
+// command, e.g. "TDS_OPT_SET TDS_OPT_CHAINXACTS", can fail it by
+// returning a Result with an Error.
+//
+// TDS_OPT_LIST commands are answered with TDS_OPT_INFO reporting the
+// argument of the last TDS_OPT_SET of the option on the connection, or
+// the default of ASE.
+func (s *Server) HandleFunc(query string, fn HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.handlers[query] = fn
+}
+
+// HandleLogin registers fn to answer logins. Without it every login
+// succeeds.
+func (s *Server) HandleLogin(fn LoginFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.loginFn = fn
+}
+
+// Params sets the types of the parameters of query, which the server
+// reports when the query is prepared. Queries with parameters cannot be
+// prepared without their types.
+func (s *Server) Params(query string, types ...asetypes.DataType) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.params[query] = types
+}
+
+// Requests returns the requests the server received.
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// results records req and returns its results.
+func (s *Server) results(req Request) []Result {
+	s.lock.Lock()
+	s.requests = append(s.requests, req)
+	fn, ok := s.handlers[req.Query]
+	if !ok && req.Kind != Option {
+		fn, ok = s.handlers[""]
+	}
+	s.lock.Unlock()
+
+	if !ok && req.Kind == Option {
+		return nil
+	}
+
+	if !ok {
+		return []Result{{
+			Error: &Message{
+				Severity: 16,
+				Text:     fmt.Sprintf("asetest: no results for query: %s", req.Query),
+			},
+		}}
+	}
+
+	return fn(req)
+}
+
+// login returns the messages sent in response to login.
+func (s *Server) login(login Login) []Message {
+	s.lock.Lock()
+	fn := s.loginFn
+	s.lock.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(login)
+}
+
+// paramTypes returns the types of the parameters of query.
+func (s *Server) paramTypes(query string) ([]asetypes.DataType, error) {
+	s.lock.Lock()
+	types, ok := s.params[query]
+	s.lock.Unlock()
+
+	if !ok && strings.Contains(query, "?") {
+		return nil, fmt.Errorf("asetest: no parameter types for query: %s", query)
+	}
+
+	return types, nil
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.addErr(fmt.Errorf("asetest: error accepting connection: %w", err))
+			}
+			return
+		}
+
+		s.lock.Lock()
+		if s.closed {
+			s.lock.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.lock.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+
+			err := newSession(s, conn).serve()
+
+			s.lock.Lock()
+			delete(s.conns, conn)
+			closed := s.closed
+			s.lock.Unlock()
+			conn.Close()
+
+			// Connections closed by the client or by Close end with
+			// read errors.
+			if err != nil && !closed && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.addErr(err)
+			}
+		}()
+	}
+}
+
+func (s *Server) addErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.errs = append(s.errs, err)
+}
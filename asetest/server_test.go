@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asetest
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase"
+	"github.com/SAP/go-dblib/asetypes"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// newTestServer returns a server that is closed when the test
+// finishes.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	server, err := NewServer()
+	if err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("error closing server: %v", err)
+		}
+	})
+
+	return server
+}
+
+// connect returns a connection to the server, which is closed when the
+// test finishes.
+func connect(t *testing.T, server *Server) *ase.Conn {
+	t.Helper()
+
+	info, err := ase.NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+	info.NoQueryCursor = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	connector, err := ase.NewConnectorWithOptions(ctx, info, ase.WithoutValidation())
+	if err != nil {
+		t.Fatalf("error creating connector: %v", err)
+	}
+
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		t.Fatalf("error connecting to server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn.(*ase.Conn)
+}
+
+func TestServerResults(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("select a, b, c from t", Result{
+		Columns: []Column{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Rows:    [][]interface{}{{int64(1), "one", true}, {int64(2), nil, false}},
+	})
+
+	conn := connect(t, server)
+
+	rows, err := conn.QueryContext(context.Background(), "select a, b, c from t", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	defer rows.Close()
+
+	if columns := rows.Columns(); !reflect.DeepEqual(columns, []string{"a", "b", "c"}) {
+		t.Errorf("unexpected columns: %v", columns)
+	}
+
+	var received [][]driver.Value
+	values := make([]driver.Value, 3)
+	for {
+		if err := rows.Next(values); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("error reading row: %v", err)
+		}
+		received = append(received, append([]driver.Value(nil), values...))
+	}
+
+	expected := [][]driver.Value{{int64(1), "one", true}, {int64(2), nil, false}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("expected rows %v, got %v", expected, received)
+	}
+}
+
+func TestServerMessages(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("print 'a'; print 'b'",
+		Result{Messages: []Message{{Text: "a"}}},
+		Result{Messages: []Message{{Text: "b"}}},
+	)
+
+	conn := connect(t, server)
+
+	var received []string
+	ctx := ase.WithMessageHandler(context.Background(), func(statement int, eed tds.EEDPackage) {
+		received = append(received, eed.Msg)
+	})
+
+	if _, err := conn.ExecContext(ctx, "print 'a'; print 'b'", nil); err != nil {
+		t.Fatalf("error executing batch: %v", err)
+	}
+
+	if !reflect.DeepEqual(received, []string{"a", "b"}) {
+		t.Errorf("unexpected messages: %q", received)
+	}
+}
+
+func TestServerError(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("raiserror 20000 'failed'", Result{
+		Error: &Message{Number: 20000, Severity: 16, Text: "failed"},
+	})
+
+	conn := connect(t, server)
+
+	_, err := conn.ExecContext(context.Background(), "raiserror 20000 'failed'", nil)
+
+	var eedError *tds.EEDError
+	if !errors.As(err, &eedError) {
+		t.Fatalf("expected EEDError, got %v", err)
+	}
+
+	if len(eedError.EEDPackages) != 1 || eedError.EEDPackages[0].MsgNumber != 20000 {
+		t.Errorf("unexpected messages: %v", eedError.EEDPackages)
+	}
+
+	// Queries without results fail.
+	_, err = conn.ExecContext(context.Background(), "select 1", nil)
+	if err == nil || !strings.Contains(err.Error(), "no results for query") {
+		t.Errorf("expected error for unscripted query, got %v", err)
+	}
+}
+
+func TestServerEnvChange(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("use test", Result{
+		EnvChanges: []EnvChange{{Type: tds.TDS_ENV_DB, Old: "master", New: "test"}},
+	})
+
+	conn := connect(t, server)
+
+	if _, err := conn.ExecContext(context.Background(), "use test", nil); err != nil {
+		t.Fatalf("error changing database: %v", err)
+	}
+
+	if database := conn.Session().Database; database != "test" {
+		t.Errorf("expected database test, got %q", database)
+	}
+}
+
+func TestServerTransaction(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("begin transaction", Result{InTransaction: true})
+	server.Handle("commit transaction", Result{})
+
+	conn := connect(t, server)
+
+	if _, err := conn.ExecContext(context.Background(), "begin transaction", nil); err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if !conn.InTransaction() {
+		t.Errorf("expected connection to be in transaction")
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "commit transaction", nil); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected connection to not be in transaction")
+	}
+}
+
+func TestServerRequests(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("delete from t where a = ?", Result{RowsAffected: 1})
+
+	conn := connect(t, server)
+
+	// Statements with parameters cannot be prepared without their
+	// types.
+	if _, err := conn.ExecContext(context.Background(), "delete from t where a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err == nil {
+		t.Errorf("expected error preparing statement without parameter types")
+	}
+
+	server.Params("delete from t where a = ?", asetypes.INT8)
+	result, err := conn.ExecContext(context.Background(), "delete from t where a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+	if err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected != 1 {
+		t.Errorf("expected 1 affected row, got %d: %v", affected, err)
+	}
+
+	expected := []Request{{Kind: Dynamic, Query: "delete from t where a = ?", Args: []interface{}{int64(1)}}}
+	if requests := server.Requests(); !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestServerFallback(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("exec p", Result{ReturnStatus: 1})
+	server.HandleFunc("", func(req Request) []Result {
+		return []Result{{RowsAffected: len(req.Query)}}
+	})
+
+	conn := connect(t, server)
+
+	_, err := conn.ExecContext(context.Background(), "exec p", nil)
+	if err == nil || !strings.Contains(err.Error(), "return status 1") {
+		t.Errorf("expected error for return status, got %v", err)
+	}
+
+	result, err := conn.ExecContext(context.Background(), "delete from t", nil)
+	if err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected != int64(len("delete from t")) {
+		t.Errorf("expected %d affected rows, got %d: %v", len("delete from t"), affected, err)
+	}
+}
+
+func TestServerOptions(t *testing.T) {
+	server := newTestServer(t)
+	server.HandleFunc("", func(req Request) []Result {
+		t.Errorf("unexpected request for fallback handler: %v", req)
+		return nil
+	})
+	server.Handle("TDS_OPT_SET TDS_OPT_TEXTSIZE", Result{
+		Error: &Message{Number: 102, Severity: 15, Text: "invalid text size"},
+	})
+
+	conn := connect(t, server)
+
+	// Options without handler are acknowledged.
+	if err := conn.SetOption(context.Background(), ase.OptionNoCount, true); err != nil {
+		t.Fatalf("error setting option: %v", err)
+	}
+
+	if err := conn.SetOption(context.Background(), ase.OptionTextSize, 1024); err == nil {
+		t.Errorf("expected error setting option failed by handler")
+	}
+
+	expected := []Request{
+		{Kind: Option, Query: "TDS_OPT_SET TDS_OPT_NOCOUNT", Args: []interface{}{[]byte{1}}},
+		{Kind: Option, Query: "TDS_OPT_SET TDS_OPT_TEXTSIZE", Args: []interface{}{[]byte{0, 4, 0, 0}}},
+	}
+	if requests := server.Requests(); !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestServerLogin(t *testing.T) {
+	server := newTestServer(t)
+
+	var logins []Login
+	server.HandleLogin(func(login Login) []Message {
+		logins = append(logins, login)
+		if login.Password != "secret" {
+			return []Message{{Number: 4002, Severity: 14, Text: "Login failed."}}
+		}
+		return nil
+	})
+
+	info, err := ase.NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+	info.Username = "user"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, password := range []string{"wrong", "secret"} {
+		info.Password = password
+
+		conn, err := ase.NewConn(ctx, info)
+		if password == "wrong" {
+			if number, ok := ase.MessageNumber(err); !ok || number != 4002 {
+				t.Errorf("expected login to fail with message 4002, got: %v", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("error connecting to server: %v", err)
+		}
+		conn.Close()
+	}
+
+	expected := []Login{{Username: "user", Password: "wrong"}, {Username: "user", Password: "secret"}}
+	if !reflect.DeepEqual(logins, expected) {
+		t.Errorf("expected logins %v, got %v", expected, logins)
+	}
+}
@@ -0,0 +1,549 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asetest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// session is the state of a connection to the server.
+type session struct {
+	server *Server
+	conn   net.Conn
+
+	// stmts maps the IDs of prepared statements to their queries.
+	stmts   map[string]string
+	cursors map[int32]*cursor
+	// lastCursorID is the ID of the last declared cursor.
+	lastCursorID int32
+	// options are the arguments of the options set on the session.
+	options map[tds.OptionCmdOption][]byte
+
+	inTransaction bool
+}
+
+// cursor is a cursor declared on a session.
+type cursor struct {
+	name  string
+	query string
+	// stmt is the ID of the prepared statement the cursor was declared
+	// with.
+	stmt string
+
+	// fetchRows is the number of rows returned per fetch.
+	fetchRows int
+	rowFmt    *rowFmtPackage
+	rows      []tds.Package
+}
+
+func newSession(server *Server, conn net.Conn) *session {
+	return &session{
+		server:  server,
+		conn:    conn,
+		stmts:   map[string]string{},
+		cursors: map[int32]*cursor{},
+		options: map[tds.OptionCmdOption][]byte{},
+	}
+}
+
+// serve performs the login and answers messages until the client logs
+// out or the connection is closed.
+func (s *session) serve() error {
+	ok, err := s.login()
+	if err != nil {
+		return fmt.Errorf("asetest: error during login: %w", err)
+	}
+
+	// The server closes the connection after a failed login.
+	if !ok {
+		return nil
+	}
+
+	for {
+		pkgs, err := readMessage(s.conn)
+		if err != nil {
+			return err
+		}
+
+		if len(pkgs) == 0 {
+			continue
+		}
+
+		if _, ok := pkgs[0].(*tds.LogoutPackage); ok {
+			return writeMessage(s.conn, &tds.DonePackage{Status: tds.TDS_DONE_FINAL})
+		}
+
+		resp, err := s.handle(pkgs)
+		if err != nil {
+			// Errors in the scripted results are reported to the
+			// client instead of failing the connection.
+			resp = s.fail(err)
+		}
+
+		if err := writeMessage(s.conn, resp...); err != nil {
+			return fmt.Errorf("asetest: error writing response: %w", err)
+		}
+	}
+}
+
+// handle returns the response to a message.
+func (s *session) handle(pkgs []tds.Package) ([]tds.Package, error) {
+	args := arguments(pkgs[1:])
+
+	switch typed := pkgs[0].(type) {
+	case *tds.LanguagePackage:
+		return s.respond(s.server.results(Request{Kind: Language, Query: typed.Cmd}))
+	case *tds.DynamicPackage:
+		return s.dynamic(typed, args)
+	case *tds.CurDeclarePackage:
+		return s.declare(typed)
+	case *tds.CurInfoPackage:
+		return s.setRows(typed)
+	case *tds.CurOpenPackage:
+		return s.open(typed, args)
+	case *tds.CurFetchPackage:
+		return s.fetch(typed)
+	case *tds.CurClosePackage:
+		return s.close(typed)
+	case *tds.OptionCmdPackage:
+		return s.option(typed)
+	}
+
+	return nil, fmt.Errorf("asetest: unsupported package %T", pkgs[0])
+}
+
+// defaultOptions are the arguments reported for options that have not
+// been set on a session, the defaults of ASE.
+var defaultOptions = map[tds.OptionCmdOption][]byte{
+	tds.TDS_OPT_DATEFIRST:    {7},
+	tds.TDS_OPT_TEXTSIZE:     {0, 0x80, 0, 0},
+	tds.TDS_OPT_ROWCOUNT:     {0, 0, 0, 0},
+	tds.TDS_OPT_DATEFORMAT:   {1},
+	tds.TDS_OPT_ISOLATION:    {1},
+	tds.TDS_OPT_ARITHABORTON: {1},
+}
+
+// option answers an option command. The arguments of TDS_OPT_SET are
+// recorded and reported in response to TDS_OPT_LIST.
+func (s *session) option(pkg *tds.OptionCmdPackage) ([]tds.Package, error) {
+	query := fmt.Sprintf("%s %s", pkg.Cmd, pkg.Option)
+	results := s.server.results(Request{Kind: Option, Query: query, Args: []interface{}{append([]byte(nil), pkg.OptionArg...)}})
+
+	resp, err := s.respond(results)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Error != nil {
+			return resp, nil
+		}
+	}
+
+	switch pkg.Cmd {
+	case tds.TDS_OPT_SET:
+		s.options[pkg.Option] = append([]byte(nil), pkg.OptionArg...)
+	case tds.TDS_OPT_LIST:
+		arg, ok := s.options[pkg.Option]
+		if !ok {
+			arg, ok = defaultOptions[pkg.Option]
+		}
+		if !ok {
+			arg = []byte{0}
+		}
+
+		info := &tds.OptionCmdPackage{Cmd: tds.TDS_OPT_INFO, Option: pkg.Option, OptionArg: arg}
+		resp = append([]tds.Package{info}, resp...)
+	}
+
+	return resp, nil
+}
+
+// arguments returns the values of the parameters in pkgs.
+func arguments(pkgs []tds.Package) []interface{} {
+	for _, pkg := range pkgs {
+		params, ok := pkg.(*tds.ParamsPackage)
+		if !ok {
+			continue
+		}
+
+		args := make([]interface{}, len(params.DataFields))
+		for i, field := range params.DataFields {
+			args[i] = field.Value()
+		}
+		return args
+	}
+
+	return nil
+}
+
+// respond returns the packages of the results and records the
+// transaction state.
+func (s *session) respond(results []Result) ([]tds.Package, error) {
+	pkgs, inTransaction, err := response(results, s.inTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("asetest: invalid result: %w", err)
+	}
+
+	s.inTransaction = inTransaction
+	return pkgs, nil
+}
+
+// fail returns a response failing the request with err.
+func (s *session) fail(err error) []tds.Package {
+	msg := Message{Severity: 16, Text: err.Error()}
+	return []tds.Package{msg.eed(), s.done(tds.TDS_DONE_ERROR, 0)}
+}
+
+// done returns a done package with the transaction state of the
+// session.
+func (s *session) done(status tds.DoneState, count int) *tds.DonePackage {
+	return done(status, count, s.inTransaction)
+}
+
+// dynamic answers the operations on prepared statements.
+func (s *session) dynamic(pkg *tds.DynamicPackage, args []interface{}) ([]tds.Package, error) {
+	ack := tds.NewDynamicPackage(true)
+	ack.Type = tds.TDS_DYN_ACK
+	ack.ID = pkg.ID
+
+	switch {
+	case pkg.Type&tds.TDS_DYN_PREPARE == tds.TDS_DYN_PREPARE:
+		query := strings.TrimPrefix(pkg.Stmt, "create proc "+pkg.ID+" as ")
+
+		types, err := s.server.paramTypes(query)
+		if err != nil {
+			return append([]tds.Package{ack}, s.fail(err)...), nil
+		}
+		s.stmts[pkg.ID] = query
+
+		resp := []tds.Package{ack}
+		if len(types) > 0 {
+			paramFmt, err := paramFmt(types)
+			if err != nil {
+				return nil, fmt.Errorf("asetest: invalid parameter types for query %s: %w", query, err)
+			}
+			resp = append(resp, paramFmt)
+		}
+		return append(resp, s.done(tds.TDS_DONE_FINAL, 0)), nil
+	case pkg.Type&tds.TDS_DYN_EXEC == tds.TDS_DYN_EXEC:
+		query, ok := s.stmts[pkg.ID]
+		if !ok {
+			return nil, fmt.Errorf("asetest: unknown statement %s", pkg.ID)
+		}
+
+		resp, err := s.respond(s.server.results(Request{Kind: Dynamic, Query: query, Args: args}))
+		if err != nil {
+			return nil, err
+		}
+		return append([]tds.Package{ack}, resp...), nil
+	case pkg.Type&tds.TDS_DYN_DEALLOC == tds.TDS_DYN_DEALLOC:
+		delete(s.stmts, pkg.ID)
+
+		// Cursors declared with the statement are deallocated with
+		// it.
+		resp := []tds.Package{ack}
+		for id, cursor := range s.cursors {
+			if cursor.stmt != pkg.ID {
+				continue
+			}
+
+			delete(s.cursors, id)
+			resp = append(resp, &tds.CurInfoPackage{
+				CursorID: id,
+				Command:  tds.TDS_CUR_CMD_INFORM,
+				Status:   tds.TDS_CUR_ISTAT_CLOSED | tds.TDS_CUR_ISTAT_DEALLOC,
+			})
+		}
+		return append(resp, s.done(tds.TDS_DONE_FINAL, 0)), nil
+	}
+
+	return nil, fmt.Errorf("asetest: unsupported dynamic operation %s", pkg.Type)
+}
+
+// cursor returns the cursor referenced by id or, if id is zero, by
+// name.
+func (s *session) cursor(id int32, name string) (int32, *cursor, error) {
+	if id != 0 {
+		if cursor, ok := s.cursors[id]; ok {
+			return id, cursor, nil
+		}
+	}
+
+	if id == 0 {
+		for id, cursor := range s.cursors {
+			if cursor.name == name {
+				return id, cursor, nil
+			}
+		}
+	}
+
+	return 0, nil, fmt.Errorf("asetest: unknown cursor %d %q", id, name)
+}
+
+// declare declares a cursor.
+func (s *session) declare(pkg *tds.CurDeclarePackage) ([]tds.Package, error) {
+	cursor := &cursor{name: pkg.Name, query: pkg.Stmt, fetchRows: 1}
+
+	if pkg.Options&tds.TDS_CUR_DOPT_DYNAMIC == tds.TDS_CUR_DOPT_DYNAMIC {
+		query, ok := s.stmts[pkg.Stmt]
+		if !ok {
+			return nil, fmt.Errorf("asetest: unknown statement %s", pkg.Stmt)
+		}
+		cursor.stmt = pkg.Stmt
+		cursor.query = query
+	}
+
+	s.lastCursorID++
+	s.cursors[s.lastCursorID] = cursor
+
+	return []tds.Package{
+		&tds.CurInfoPackage{
+			CursorID: s.lastCursorID,
+			Command:  tds.TDS_CUR_CMD_INFORM,
+			Status:   tds.TDS_CUR_ISTAT_DECLARED,
+		},
+		s.done(tds.TDS_DONE_FINAL, 0),
+	}, nil
+}
+
+// setRows sets the number of rows returned per fetch.
+func (s *session) setRows(pkg *tds.CurInfoPackage) ([]tds.Package, error) {
+	if pkg.Command != tds.TDS_CUR_CMD_SETCURROWS {
+		return nil, fmt.Errorf("asetest: unsupported cursor command %s", pkg.Command)
+	}
+
+	id, cursor, err := s.cursor(pkg.CursorID, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if pkg.RowCount > 0 {
+		cursor.fetchRows = int(pkg.RowCount)
+	}
+
+	return []tds.Package{
+		&tds.CurInfoPackage{
+			CursorID: id,
+			Command:  tds.TDS_CUR_CMD_INFORM,
+			Status:   tds.TDS_CUR_ISTAT_DECLARED | tds.TDS_CUR_ISTAT_ROWCNT,
+			RowCount: int32(cursor.fetchRows),
+		},
+		s.done(tds.TDS_DONE_FINAL, 0),
+	}, nil
+}
+
+// open opens a cursor with the first result of its query.
+func (s *session) open(pkg *tds.CurOpenPackage, args []interface{}) ([]tds.Package, error) {
+	id, cursor, err := s.cursor(pkg.CursorID, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.server.results(Request{Kind: Cursor, Query: cursor.query, Args: args})
+	if len(results) == 0 {
+		return nil, fmt.Errorf("asetest: no result for cursor query %s", cursor.query)
+	}
+	result := results[0]
+
+	resp := result.prelude()
+	if result.Error != nil {
+		return append(resp, result.Error.eed(), s.done(tds.TDS_DONE_ERROR, 0)), nil
+	}
+
+	cursor.rowFmt, err = result.rowFmt()
+	if err != nil {
+		return nil, fmt.Errorf("asetest: invalid result: %w", err)
+	}
+
+	cursor.rows, err = rows(cursor.rowFmt, result.Rows)
+	if err != nil {
+		return nil, fmt.Errorf("asetest: invalid result: %w", err)
+	}
+
+	return append(resp,
+		&tds.CurInfoPackage{
+			CursorID: id,
+			Command:  tds.TDS_CUR_CMD_INFORM,
+			Status:   tds.TDS_CUR_ISTAT_OPEN,
+		},
+		cursor.rowFmt,
+		s.done(tds.TDS_DONE_FINAL, 0),
+	), nil
+}
+
+// fetch returns the next rows of a cursor.
+func (s *session) fetch(pkg *tds.CurFetchPackage) ([]tds.Package, error) {
+	if pkg.Type != tds.TDS_CUR_NEXT {
+		return nil, fmt.Errorf("asetest: unsupported fetch type %s", pkg.Type)
+	}
+
+	_, cursor, err := s.cursor(pkg.CursorID, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	n := cursor.fetchRows
+	if n > len(cursor.rows) {
+		n = len(cursor.rows)
+	}
+
+	resp := append([]tds.Package{}, cursor.rows[:n]...)
+	cursor.rows = cursor.rows[n:]
+
+	return append(resp, s.done(tds.TDS_DONE_COUNT, n)), nil
+}
+
+// close closes and deallocates a cursor.
+func (s *session) close(pkg *tds.CurClosePackage) ([]tds.Package, error) {
+	id, _, err := s.cursor(pkg.CursorID, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := tds.TDS_CUR_ISTAT_CLOSED
+	if pkg.Options&tds.TDS_CUR_COPT_DEALLOC == tds.TDS_CUR_COPT_DEALLOC {
+		delete(s.cursors, id)
+		status |= tds.TDS_CUR_ISTAT_DEALLOC
+	}
+
+	return []tds.Package{
+		&tds.CurInfoPackage{
+			CursorID: id,
+			Command:  tds.TDS_CUR_CMD_INFORM,
+			Status:   status,
+		},
+		s.done(tds.TDS_DONE_FINAL, 0),
+	}, nil
+}
+
+// login performs the login negotiation with TDS_MSG_SEC_ENCRYPT4. It
+// returns false if the login was rejected by the login handler.
+func (s *session) login() (bool, error) {
+	data, err := readData(s.conn)
+	if err != nil {
+		return false, err
+	}
+
+	if len(data) < loginRecordSize {
+		return false, fmt.Errorf("login message too short: %d bytes", len(data))
+	}
+	login := Login{Username: loginUsername(data)}
+
+	pkgs, err := parsePackages(data[loginRecordSize:])
+	if err != nil {
+		return false, err
+	}
+
+	var caps *tds.CapabilityPackage
+	for _, pkg := range pkgs {
+		if typed, ok := pkg.(*tds.CapabilityPackage); ok {
+			caps = typed
+		}
+	}
+	if caps == nil {
+		return false, fmt.Errorf("login message without capabilities")
+	}
+
+	pubKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&serverKey.PublicKey),
+	})
+
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, err
+	}
+
+	paramFmt, paramsPkg, err := params(int32(1), pubKey, nonce)
+	if err != nil {
+		return false, err
+	}
+
+	if err := writeMessage(s.conn,
+		loginAck(tds.TDS_LOG_NEGOTIATE),
+		tds.NewMsgPackage(tds.TDS_MSG_HASARGS, tds.TDS_MSG_SEC_ENCRYPT4),
+		paramFmt, paramsPkg,
+		&tds.DonePackage{Status: tds.TDS_DONE_FINAL},
+	); err != nil {
+		return false, err
+	}
+
+	// The client responds with the encrypted password, remote server
+	// passwords and the symmetric key.
+	pkgs, err = readMessage(s.conn)
+	if err != nil {
+		return false, err
+	}
+
+	for i, pkg := range pkgs {
+		msg, ok := pkg.(*tds.MsgPackage)
+		if !ok || msg.MsgId != tds.TDS_MSG_SEC_LOGPWD3 || i+2 >= len(pkgs) {
+			continue
+		}
+
+		password, ok := pkgs[i+2].(*tds.ParamsPackage)
+		if !ok || len(password.DataFields) != 1 {
+			return false, fmt.Errorf("expected password parameter, got %v", pkgs[i+2])
+		}
+
+		encrypted, _ := password.DataFields[0].Value().([]byte)
+		decrypted, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, serverKey, encrypted, []byte{})
+		if err != nil {
+			return false, fmt.Errorf("error decrypting password: %w", err)
+		}
+
+		// The password is encrypted with the nonce as prefix.
+		if len(decrypted) < len(nonce) {
+			return false, fmt.Errorf("decrypted password too short: %d bytes", len(decrypted))
+		}
+		login.Password = string(decrypted[len(nonce):])
+	}
+
+	var resp []tds.Package
+	status := tds.TDS_LOG_SUCCEED
+	for _, msg := range s.server.login(login) {
+		resp = append(resp, msg.eed())
+		if msg.Severity > 10 {
+			status = tds.TDS_LOG_FAIL
+		}
+	}
+
+	resp = append(resp, loginAck(status))
+	if status == tds.TDS_LOG_SUCCEED {
+		resp = append(resp, caps)
+	}
+	resp = append(resp, &tds.DonePackage{Status: tds.TDS_DONE_FINAL})
+
+	return status == tds.TDS_LOG_SUCCEED, writeMessage(s.conn, resp...)
+}
+
+// programVersion is the version of the server reported in the login
+// acknowledgement.
+var programVersion = []byte{16, 0, 4, 3}
+
+func loginAck(status tds.LoginAckStatus) *tds.LoginAckPackage {
+	version, _ := tds.NewVersion([]byte{5, 0, 0, 0})
+	serverVersion, _ := tds.NewVersion(programVersion)
+
+	return &tds.LoginAckPackage{
+		// status, version, name length, name, program version
+		Length:         uint16(1 + 4 + 1 + len(serverName) + 4),
+		Status:         status,
+		Version:        version,
+		NameLength:     uint8(len(serverName)),
+		ProgramName:    serverName,
+		ProgramVersion: serverVersion,
+	}
+}
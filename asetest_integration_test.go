@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !integration
+// +build !integration
+
+package ase
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/asetypes"
+	"github.com/SAP/go-dblib/integration"
+)
+
+// TestMain registers a table server with the integration package,
+// which runs the scenarios of integration_test.go against it.
+func TestMain(m *testing.M) {
+	if err := testMain(m); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func testMain(m *testing.M) error {
+	server, err := newTableServer()
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	info, err := NewInfo()
+	if err != nil {
+		return err
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+	info.Username, info.Password = "user", "password"
+
+	newConnectorFn := func(info interface{}) (driver.Connector, error) {
+		return NewConnector(info.(*Info))
+	}
+
+	if err := integration.RegisterDSN("asetest", info, newConnectorFn); err != nil {
+		return fmt.Errorf("error registering table server: %w", err)
+	}
+
+	if rc := m.Run(); rc != 0 {
+		return fmt.Errorf("tests failed with %d", rc)
+	}
+
+	return nil
+}
+
+// tableTypes are the data types of the column types in the scenarios.
+//
+// Nullable columns, decimal, bigtime, bigdatetime and the large object
+// types are not supported, as asetest does not report the length,
+// precision and scale of columns.
+var tableTypes = map[string]asetypes.DataType{
+	"bigint":            asetypes.INT8,
+	"int":               asetypes.INT4,
+	"smallint":          asetypes.INT2,
+	"tinyint":           asetypes.INT1,
+	"unsigned bigint":   asetypes.UINT8,
+	"unsigned int":      asetypes.UINT4,
+	"unsigned smallint": asetypes.UINT2,
+	"money":             asetypes.MONEY,
+	"smallmoney":        asetypes.SHORTMONEY,
+	"float":             asetypes.FLT8,
+	"real":              asetypes.FLT4,
+	"bit":               asetypes.BIT,
+	"char":              asetypes.CHAR,
+	"varchar":           asetypes.VARCHAR,
+	"nchar":             asetypes.CHAR,
+	"nvarchar":          asetypes.VARCHAR,
+	"binary":            asetypes.BINARY,
+	"varbinary":         asetypes.VARBINARY,
+	"date":              asetypes.DATE,
+	"time":              asetypes.TIME,
+	"smalldatetime":     asetypes.SHORTDATE,
+	"datetime":          asetypes.DATETIME,
+}
+
+var (
+	createTable = regexp.MustCompile(`^create table (\w+) \((.*)\)$`)
+	insertInto  = regexp.MustCompile(`^insert into (\w+)(?: \(a\))? values \(.*\)$`)
+	selectFrom  = regexp.MustCompile(`^select \* from (\w+)$`)
+	dropTable   = regexp.MustCompile(`^drop table (\w+)$`)
+)
+
+// table is a table of the table server.
+type table struct {
+	columns []asetest.Column
+	// padded are the lengths char columns are padded to.
+	padded map[int]int
+	rows   [][]interface{}
+}
+
+// tableServer is a server keeping the tables created by the scenarios
+// in memory. Rows inserted in a transaction are added at its commit.
+type tableServer struct {
+	*asetest.Server
+
+	lock          sync.Mutex
+	tables        map[string]*table
+	inTransaction bool
+	pending       map[string][][]interface{}
+}
+
+func newTableServer() (*tableServer, error) {
+	server, err := asetest.NewServer()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &tableServer{Server: server, tables: map[string]*table{}}
+	s.HandleFunc("", s.handle)
+	return s, nil
+}
+
+func (s *tableServer) handle(req asetest.Request) []asetest.Result {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var result asetest.Result
+	var err error
+
+	switch {
+	case strings.HasPrefix(req.Query, "begin transaction"):
+		s.inTransaction = true
+		s.pending = map[string][][]interface{}{}
+	case strings.HasPrefix(req.Query, "commit"):
+		for name, rows := range s.pending {
+			if tbl, ok := s.tables[name]; ok {
+				tbl.rows = append(tbl.rows, rows...)
+			}
+		}
+		s.inTransaction = false
+	case strings.HasPrefix(req.Query, "rollback"):
+		s.inTransaction = false
+	case createTable.MatchString(req.Query):
+		err = s.create(createTable.FindStringSubmatch(req.Query))
+	case insertInto.MatchString(req.Query):
+		result, err = s.insert(insertInto.FindStringSubmatch(req.Query)[1], req.Args)
+	case selectFrom.MatchString(req.Query):
+		result, err = s.selectAll(selectFrom.FindStringSubmatch(req.Query)[1])
+	case dropTable.MatchString(req.Query):
+		delete(s.tables, dropTable.FindStringSubmatch(req.Query)[1])
+	default:
+		err = fmt.Errorf("unsupported query: %s", req.Query)
+	}
+
+	if err != nil {
+		return []asetest.Result{{Error: &asetest.Message{Severity: 16, Text: err.Error()}}}
+	}
+
+	result.InTransaction = s.inTransaction
+	return []asetest.Result{result}
+}
+
+// create creates the table of a create table statement and registers
+// the parameters of its insert statements.
+func (s *tableServer) create(match []string) error {
+	name := match[1]
+	if _, ok := s.tables[name]; ok {
+		return fmt.Errorf("table %s already exists", name)
+	}
+
+	tbl := &table{padded: map[int]int{}}
+	var types []asetypes.DataType
+	var placeholders []string
+	for _, def := range strings.Split(match[2], ", ") {
+		fields := strings.SplitN(def, " ", 2)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid column definition: %s", def)
+		}
+
+		typeName := strings.TrimSuffix(fields[1], " null")
+		length := 0
+		if i := strings.Index(typeName, "("); i >= 0 {
+			fmt.Sscanf(typeName[i:], "(%d)", &length)
+			typeName = typeName[:i]
+		}
+
+		switch typeName {
+		case "char":
+			tbl.padded[len(tbl.columns)] = length
+		case "nchar":
+			// The length of nchar is the maximum length of utf8
+			// characters in ASE.
+			tbl.padded[len(tbl.columns)] = 3 * length
+		}
+
+		dataType, ok := tableTypes[typeName]
+		if !ok {
+			return fmt.Errorf("unsupported column type: %s", fields[1])
+		}
+
+		tbl.columns = append(tbl.columns, asetest.Column{Name: fields[0], Type: dataType})
+		types = append(types, dataType)
+		placeholders = append(placeholders, "?")
+	}
+	s.tables[name] = tbl
+
+	s.Params(fmt.Sprintf("insert into %s values (%s)", name, strings.Join(placeholders, ", ")), types...)
+	if len(types) == 1 {
+		s.Params(fmt.Sprintf("insert into %s (a) values (?)", name), types...)
+	}
+
+	return nil
+}
+
+func (s *tableServer) insert(name string, args []interface{}) (asetest.Result, error) {
+	tbl, ok := s.tables[name]
+	if !ok {
+		return asetest.Result{}, fmt.Errorf("table %s does not exist", name)
+	}
+
+	if len(args) != len(tbl.columns) {
+		return asetest.Result{}, fmt.Errorf("expected %d values, got %d", len(tbl.columns), len(args))
+	}
+
+	row := make([]interface{}, len(args))
+	for i, arg := range args {
+		if str, ok := arg.(string); ok && tbl.padded[i] > 0 {
+			arg = fmt.Sprintf("%-*s", tbl.padded[i], str)
+		}
+		row[i] = arg
+	}
+
+	if s.inTransaction {
+		s.pending[name] = append(s.pending[name], row)
+	} else {
+		tbl.rows = append(tbl.rows, row)
+	}
+
+	return asetest.Result{RowsAffected: 1}, nil
+}
+
+func (s *tableServer) selectAll(name string) (asetest.Result, error) {
+	tbl, ok := s.tables[name]
+	if !ok {
+		return asetest.Result{}, fmt.Errorf("table %s does not exist", name)
+	}
+
+	return asetest.Result{Columns: tbl.columns, Rows: tbl.rows}, nil
+}
+
+// Exact numeric integer
+func TestScriptedInt8(t *testing.T)  { integration.DoTestBigInt(t) }
+func TestScriptedInt4(t *testing.T)  { integration.DoTestInt(t) }
+func TestScriptedInt2(t *testing.T)  { integration.DoTestSmallInt(t) }
+func TestScriptedInt1(t *testing.T)  { integration.DoTestTinyInt(t) }
+func TestScriptedUint8(t *testing.T) { integration.DoTestUnsignedBigInt(t) }
+func TestScriptedUint4(t *testing.T) { integration.DoTestUnsignedInt(t) }
+func TestScriptedUint2(t *testing.T) { integration.DoTestUnsignedSmallInt(t) }
+
+// Approximate numeric
+func TestScriptedFlt8(t *testing.T) { integration.DoTestFloat(t) }
+func TestScriptedFlt4(t *testing.T) { integration.DoTestReal(t) }
+
+// Money
+func TestScriptedMoney(t *testing.T)      { integration.DoTestMoney(t) }
+func TestScriptedShortmoney(t *testing.T) { integration.DoTestMoney4(t) }
+
+// Date and time
+func TestScriptedDateN(t *testing.T)         { integration.DoTestDate(t) }
+func TestScriptedTimeN(t *testing.T)         { integration.DoTestTime(t) }
+func TestScriptedSmallDateTime(t *testing.T) { integration.DoTestSmallDateTime(t) }
+func TestScriptedDateTime(t *testing.T)      { integration.DoTestDateTime(t) }
+
+// Character
+func TestScriptedChar(t *testing.T)     { integration.DoTestChar(t) }
+func TestScriptedNChar(t *testing.T)    { integration.DoTestNChar(t) }
+func TestScriptedVarChar(t *testing.T)  { integration.DoTestVarChar(t) }
+func TestScriptedNVarChar(t *testing.T) { integration.DoTestNVarChar(t) }
+
+// Binary
+func TestScriptedBinary(t *testing.T)    { integration.DoTestBinary(t) }
+func TestScriptedVarBinary(t *testing.T) { integration.DoTestVarBinary(t) }
+
+// Bit
+func TestScriptedBit(t *testing.T) { integration.DoTestBit(t) }
+
+// Routines
+func TestScriptedSQLTx(t *testing.T)   { integration.DoTestSQLTx(t) }
+func TestScriptedSQLExec(t *testing.T) { integration.DoTestSQLExec(t) }
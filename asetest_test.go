@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// testServer is a scripted server for the tests of the package.
+type testServer struct {
+	*asetest.Server
+	t *testing.T
+}
+
+// newTestServer returns a server that is closed when the test
+// finishes.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	server, err := asetest.NewServer()
+	if err != nil {
+		t.Fatalf("error starting server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("error closing server: %v", err)
+		}
+	})
+
+	return &testServer{Server: server, t: t}
+}
+
+// connector returns a connector opening connections to the server.
+func (s *testServer) connector(opts ...ConnectorOption) *Connector {
+	s.t.Helper()
+
+	info, err := NewInfo()
+	if err != nil {
+		s.t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = s.Host(), s.Port()
+
+	connector, err := NewConnectorWithOptions(context.Background(), info,
+		append([]ConnectorOption{WithoutValidation()}, opts...)...)
+	if err != nil {
+		s.t.Fatalf("error creating connector: %v", err)
+	}
+
+	return connector
+}
+
+// connect returns a connection to the server, which is closed when the
+// test finishes.
+func (s *testServer) connect(opts ...ConnectorOption) *Conn {
+	s.t.Helper()
+
+	connector := s.connector(opts...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		s.t.Fatalf("error connecting to server: %v", err)
+	}
+	s.t.Cleanup(func() { conn.Close() })
+
+	return conn.(*Conn)
+}
+
+// commands returns the received language commands and option commands
+// as strings.
+func (s *testServer) commands() []string {
+	var commands []string
+	for _, req := range s.Requests() {
+		switch req.Kind {
+		case asetest.Language:
+			commands = append(commands, req.Query)
+		case asetest.Option:
+			commands = append(commands, fmt.Sprintf("%s %v", req.Query, req.Args[0]))
+		}
+	}
+	return commands
+}
+
+// scriptedTable is the table whose queries are answered by
+// newScriptedServer.
+const scriptedTable = "scripted"
+
+// newScriptedServer returns a server answering the queries of the
+// scenarios with the table created by createTable in the integration
+// tests.
+func newScriptedServer(t *testing.T) *testServer {
+	t.Helper()
+
+	server := newTestServer(t)
+
+	columns := []asetest.Column{{Name: "a", Type: asetypes.INT8}, {Name: "b", Type: asetypes.VARCHAR}}
+	rows := [][]interface{}{{int64(1), "one"}, {int64(2), "two"}, {int64(3), "three"}, {int64(4), "four"}}
+
+	// like is only used with literal values.
+	matching := func(req asetest.Request) [][]interface{} {
+		var matched [][]interface{}
+		for _, row := range rows {
+			if len(req.Args) == 1 && row[1] == req.Args[0] {
+				matched = append(matched, row)
+			}
+		}
+		return matched
+	}
+
+	server.Handle("select * from "+scriptedTable, asetest.Result{Columns: columns, Rows: rows})
+
+	for _, query := range []string{
+		"select * from " + scriptedTable + " where b like (?)",
+		"select * from " + scriptedTable + " where b like ?",
+	} {
+		server.Params(query, asetypes.VARCHAR)
+		server.HandleFunc(query, func(req asetest.Request) []asetest.Result {
+			return []asetest.Result{{Columns: columns, Rows: matching(req)}}
+		})
+	}
+
+	server.Handle("update "+scriptedTable+` set b = "five"`, asetest.Result{RowsAffected: len(rows)})
+
+	query := "update " + scriptedTable + ` set b = "five" where b like ?`
+	server.Params(query, asetypes.VARCHAR)
+	server.HandleFunc(query, func(req asetest.Request) []asetest.Result {
+		return []asetest.Result{{RowsAffected: len(matching(req))}}
+	})
+
+	return server
+}
+
+// scriptedWrapper runs a scenario on a connection to the server.
+func scriptedWrapper(t *testing.T, server *testServer, runner func(*testing.T, *Conn, string)) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := server.connector().Connect(ctx)
+	if err != nil {
+		t.Fatalf("error connecting to server: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("error closing connection: %v", err)
+		}
+	}()
+
+	runner(t, conn.(*Conn), scriptedTable)
+}
+
+func TestScriptedCursorClose(t *testing.T) {
+	query := "select * from " + scriptedTable
+	cursor := asetest.Request{Kind: asetest.Cursor, Query: query}
+	cursorWithArgs := asetest.Request{Kind: asetest.Cursor, Query: query + " where b like (?)", Args: []interface{}{"two"}}
+
+	cases := []struct {
+		name     string
+		scenario func(*testing.T, *Conn, string)
+		requests []asetest.Request
+	}{
+		{"SingleCursor", singleCursor, []asetest.Request{cursor}},
+		{"SingleCursorWithArgs", singleCursorWithArgs, []asetest.Request{cursorWithArgs}},
+		{"TwoCursors", twoCursors, []asetest.Request{cursor, cursor}},
+		{"TwoCursorsOneWithArgs", twoCursorsOneWithArgs, []asetest.Request{cursor, cursorWithArgs}},
+		{"TwoCursorsWithArgs", twoCursorsWithArgs, []asetest.Request{cursorWithArgs, cursorWithArgs}},
+		{"TwoCursorsOneWithArgs2", twoCursorsOneWithArgs2, []asetest.Request{cursorWithArgs, cursor}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t)
+			scriptedWrapper(t, server, tc.scenario)
+
+			if requests := server.Requests(); !reflect.DeepEqual(requests, tc.requests) {
+				t.Errorf("expected requests %v, got %v", tc.requests, requests)
+			}
+		})
+	}
+}
+
+func TestScriptedDirectExec(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected asetest.Request
+	}{
+		{
+			// A nil argument prepares the statement.
+			"SelectNoArgs", "select * from %s", []interface{}{nil},
+			asetest.Request{Kind: asetest.Dynamic, Query: "select * from " + scriptedTable},
+		},
+		{
+			"SelectWithArgs", "select * from %s where b like ?", []interface{}{"three"},
+			asetest.Request{Kind: asetest.Dynamic, Query: "select * from " + scriptedTable + " where b like ?", Args: []interface{}{"three"}},
+		},
+		{
+			"UpdateNoArgs", `update %s set b = "five"`, nil,
+			asetest.Request{Kind: asetest.Language, Query: "update " + scriptedTable + ` set b = "five"`},
+		},
+		{
+			"UpdateWithArgs", `update %s set b = "five" where b like ?`, []interface{}{"three"},
+			asetest.Request{Kind: asetest.Dynamic, Query: "update " + scriptedTable + ` set b = "five" where b like ?`, Args: []interface{}{"three"}},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			server := newScriptedServer(t)
+			scriptedWrapper(t, server, directExec(tc.query, tc.args...))
+
+			expected := []asetest.Request{tc.expected}
+			if requests := server.Requests(); !reflect.DeepEqual(requests, expected) {
+				t.Errorf("expected requests %v, got %v", expected, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentExec(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect()
+	defer conn.Close()
+
+	const goroutines, execs = 8, 10
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < execs; j++ {
+				msg := fmt.Sprintf("%d-%d", i, j)
+
+				recorder := messageRecorder{}
+				ctx := WithMessageHandler(context.Background(), recorder.handle)
+
+				if _, err := conn.ExecContext(ctx, "print '"+msg+"'", nil); err != nil {
+					t.Errorf("error executing statement: %v", err)
+					return
+				}
+
+				if len(recorder) != 1 || recorder[0] != "0: "+msg {
+					t.Errorf("expected message of own statement %q, received %v", msg, recorder)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := conn.Stats(); stats.RoundTrips < goroutines*execs {
+		t.Errorf("expected at least %d round trips, got %d", goroutines*execs, stats.RoundTrips)
+	}
+}
+
+// withNoQueryCursor is a ConnectorOption reading the results of queries
+// directly instead of through cursors.
+func withNoQueryCursor(c *Connector) error {
+	c.Info.NoQueryCursor = true
+	return nil
+}
+
+func TestConcurrentQuery(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect(withNoQueryCursor)
+	defer conn.Close()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				rows, err := conn.QueryContext(context.Background(), "select 1", nil)
+				if errors.Is(err, ErrResultSetOpen) {
+					// Another goroutine is reading its rows.
+					continue
+				}
+				if err != nil {
+					t.Errorf("error executing query: %v", err)
+					return
+				}
+
+				values := make([]driver.Value, 1)
+				if err := rows.Next(values); err != nil {
+					t.Errorf("error reading row: %v", err)
+				}
+
+				if err := rows.Close(); err != nil {
+					t.Errorf("error closing rows: %v", err)
+				}
+
+				conn.InTransaction()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestResultSetOpen(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect(withNoQueryCursor)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "select 1", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "print 'a'", nil); !errors.Is(err, ErrResultSetOpen) {
+		t.Errorf("expected ErrResultSetOpen, got %v", err)
+	}
+
+	if _, err := conn.NewStmt(context.Background(), "", "select ?", true); !errors.Is(err, ErrResultSetOpen) {
+		t.Errorf("expected ErrResultSetOpen preparing statement, got %v", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Fatalf("error closing rows: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "print 'a'", nil); err != nil {
+		t.Errorf("error executing statement after closing rows: %v", err)
+	}
+
+	// Closing the rows again must not release the connection of
+	// another exchange.
+	if err := rows.Close(); err != nil {
+		t.Errorf("error closing closed rows: %v", err)
+	}
+}
+
+func TestResultSetOpenEmpty(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("update t set a = 1")
+	server.Handle("update t set a = 2")
+	conn := server.connect(withNoQueryCursor)
+	defer conn.Close()
+
+	// Rows of statements without result set do not occupy the
+	// connection.
+	if _, err := conn.QueryContext(context.Background(), "update t set a = 1", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "update t set a = 2", nil); err != nil {
+		t.Errorf("error executing statement: %v", err)
+	}
+}
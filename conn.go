@@ -8,7 +8,9 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
+	"net"
 	"sync"
+	"time"
 
 	"github.com/SAP/go-dblib/asetypes"
 	"github.com/SAP/go-dblib/tds"
@@ -24,15 +26,80 @@ var (
 )
 
 // Conn implements the driver.Conn interface.
+//
+// Calls on a Conn are serialized, see ErrResultSetOpen.
 type Conn struct {
 	Conn    *tds.Conn
 	Channel *tds.Channel
 	Info    *Info
 
-	// TODO I don't particularly like locking statements like this
-	stmts map[int]*Stmt
-	// TODO: iirc conns aren't used in multiple threads at the same time
-	stmtLock *sync.RWMutex
+	// channel is the channel the exchanges with the server use, which
+	// is Channel unless replaced by tests.
+	channel tdsChannel
+
+	// lock serializes the exchanges with the server, see acquire.
+	lock *sync.Mutex
+	// openRows is the result set that has not been closed yet, which
+	// must be closed or buffered before another command can be sent.
+	openRows *Rows
+	// multipleResultSets permits commands while rows are open, see
+	// WithMultipleResultSets.
+	multipleResultSets bool
+	// maxBufferedRows is the maximum number of rows of a response read
+	// into memory for multipleResultSets.
+	maxBufferedRows int
+	// buffer is the response read by the current exchange instead of
+	// the channel.
+	buffer *[]tds.Package
+	// stmtCache caches the statements prepared by GenericExec, nil if
+	// the cache is disabled.
+	stmtCache *stmtCache
+
+	// loginMessages are the EEDPackages received during login.
+	loginMessages []tds.EEDPackage
+
+	// initStatements are executed after the connection has been
+	// established and when the session is reset.
+	initStatements []string
+
+	// chained is true if the connection is in chained transaction
+	// mode.
+	chained bool
+	// inTransaction is true if the last response reported an open
+	// transaction.
+	inTransaction bool
+	// pendingDone is true if go-dblib inserts a TDS_DONE_FINAL after
+	// the last received DonePackage.
+	pendingDone bool
+
+	// env is the session state reported by the server.
+	env *connEnv
+	// database is the database after the connection was established,
+	// which is restored by ResetSession.
+	database string
+	// serverInfo is built from the login acknowledgement and completed
+	// by the first call to ServerInfo.
+	serverInfo *ServerInfo
+	// serverInfoQueried is true if serverInfo has been completed.
+	serverInfoQueried bool
+	// serverInfoLock guards serverInfo.
+	serverInfoLock *sync.Mutex
+
+	// tracer receives events at the boundaries of database calls.
+	tracer Tracer
+	// messages passes the messages of the current call to its
+	// MessageHandler.
+	messages *messages
+	// stats collects the counters returned by Stats.
+	stats *statsCollector
+	// logger passes log entries to the Logger of the connector.
+	logger connLogger
+
+	// tx is the transaction begun on the connection that has not been
+	// committed or rolled back.
+	tx *Transaction
+	// xa is the transaction branch associated with the connection.
+	xa *xaBranch
 }
 
 // NewConn returns a connection with the passed configuration.
@@ -41,19 +108,53 @@ func NewConn(ctx context.Context, dsn *Info) (*Conn, error) {
 }
 
 // NewConnWithHooks returns a connection with the passed configuration.
+//
+// Connections using a Dialer or any other option of the Connector are
+// opened with Connector.Connect.
 func NewConnWithHooks(ctx context.Context, info *Info, envChangeHooks []tds.EnvChangeHook, eedHooks []tds.EEDHook) (*Conn, error) {
+	connector := &Connector{
+		Info:           info,
+		EnvChangeHooks: envChangeHooks,
+		EEDHooks:       eedHooks,
+	}
+
+	return connector.newConn(ctx, info.Password)
+}
+
+// newConn opens a connection with the configuration of the connector,
+// logging in with the passed password.
+func (c *Connector) newConn(ctx context.Context, password string) (*Conn, error) {
+	info := c.Info
+
 	conn := &Conn{
-		Info:     info,
-		stmts:    map[int]*Stmt{},
-		stmtLock: &sync.RWMutex{},
+		Info:               info,
+		lock:               &sync.Mutex{},
+		serverInfoLock:     &sync.Mutex{},
+		multipleResultSets: c.MultipleResultSets,
+		maxBufferedRows:    c.maxBufferedRows(),
+		messages:           &messages{},
+		initStatements:     c.initStatements(),
+		env:                &connEnv{},
+		stats:              newStatsCollector(c.drv().statsCollector()),
+		logger:             c.newConnLogger(),
+	}
+	conn.tracer = statsTracer{
+		stats: conn.stats,
+		next:  logTracer{logger: conn.logger, next: c.tracer()},
+	}
+	if size := c.stmtCacheSize(); size > 0 {
+		conn.stmtCache = newStmtCache(size)
 	}
 
-	// Cannot pass the passed context along here as tds.NewConn creates
-	// a child context from the passed context.
-	// Otherwise the context isn't being used, so using
-	// context.Background is fine.
+	start := time.Now()
 	var err error
-	conn.Conn, err = tds.NewConn(context.Background(), &info.Info)
+	conn.Conn, err = c.openTDSConn(ctx, conn.stats)
+	conn.tracer.Connect(ctx, ConnectEvent{
+		Network:  info.Network,
+		Address:  net.JoinHostPort(info.Host, info.Port),
+		Err:      err,
+		Duration: time.Since(start),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("go-ase: error opening connection to TDS server: %w", err)
 	}
@@ -63,32 +164,43 @@ func NewConnWithHooks(ctx context.Context, info *Info, envChangeHooks []tds.EnvC
 		conn.Close()
 		return nil, fmt.Errorf("go-ase: error opening logical channel: %w", err)
 	}
+	conn.channel = conn.Channel
 
-	if drv.envChangeHooks != nil {
-		if err := conn.Channel.RegisterEnvChangeHooks(drv.envChangeHooks...); err != nil {
-			return nil, fmt.Errorf("go-ase: error registering driver EnvChangeHooks: %w", err)
-		}
+	if err := conn.Channel.RegisterEnvChangeHooks(conn.env.hook); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("go-ase: error registering environment EnvChangeHook: %w", err)
+	}
+
+	if err := conn.Channel.RegisterEnvChangeHooks(c.drv().envChangeHook); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("go-ase: error registering driver EnvChangeHooks: %w", err)
 	}
 
-	if envChangeHooks != nil {
-		if err := conn.Channel.RegisterEnvChangeHooks(envChangeHooks...); err != nil {
+	if c.EnvChangeHooks != nil {
+		if err := conn.Channel.RegisterEnvChangeHooks(c.EnvChangeHooks...); err != nil {
+			conn.Close()
 			return nil, fmt.Errorf("go-ase: error registering argument EnvChangeHooks: %w", err)
 		}
 	}
 
-	if drv.eedHooks != nil {
-		if err := conn.Channel.RegisterEEDHooks(drv.eedHooks...); err != nil {
-			return nil, fmt.Errorf("go-ase: error registering driver EEDHooks: %w", err)
-		}
+	if err := conn.Channel.RegisterEEDHooks(c.drv().eedHook); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("go-ase: error registering driver EEDHooks: %w", err)
 	}
 
-	if eedHooks != nil {
-		if err := conn.Channel.RegisterEEDHooks(eedHooks...); err != nil {
+	if c.EEDHooks != nil {
+		if err := conn.Channel.RegisterEEDHooks(c.EEDHooks...); err != nil {
+			conn.Close()
 			return nil, fmt.Errorf("go-ase: error registering argument EEDHooks: %w", err)
 		}
 	}
 
-	loginConfig, err := tds.NewLoginConfig(&info.Info)
+	// The password is set on a copy to not leak passwords from
+	// a CredentialsProvider into the shared info.
+	loginInfo := info.Info
+	loginInfo.Password = password
+
+	loginConfig, err := tds.NewLoginConfig(&loginInfo)
 	if err != nil {
 		conn.Close()
 		return nil, fmt.Errorf("go-ase: error creating login config: %w", err)
@@ -96,24 +208,65 @@ func NewConnWithHooks(ctx context.Context, info *Info, envChangeHooks []tds.EnvC
 
 	loginConfig.AppName = info.AppName
 
-	if err := conn.Channel.Login(ctx, loginConfig); err != nil {
+	recorder := &loginRecorder{active: true}
+	if err := conn.Channel.RegisterEEDHooks(recorder.hook); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("go-ase: error registering login EEDHook: %w", err)
+	}
+
+	start = time.Now()
+	err = conn.Channel.Login(ctx, loginConfig)
+	conn.loginMessages = recorder.stop()
+	conn.tracer.Login(ctx, LoginEvent{
+		Username: info.Username,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+
+	if eed, ok := passwordExpired(conn.loginMessages); ok && (err != nil || !c.allowExpiredPassword) {
+		conn.Close()
+		return nil, &PasswordExpiredError{EED: eed, Err: err}
+	}
+
+	if err != nil {
 		conn.Close()
 		return nil, fmt.Errorf("go-ase: error logging in: %w", err)
 	}
 
+	conn.serverInfo = newServerInfo(conn.Channel.LoginAck(), conn.Conn.Caps)
+
 	// TODO can this be passed another way?
 	if info.Database != "" {
 		if _, err = conn.ExecContext(ctx, "use "+info.Database, nil); err != nil {
+			conn.Close()
 			return nil, fmt.Errorf("go-ase: error switching to database %s: %w", info.Database, err)
 		}
 	}
 
+	if err := conn.applyInitStatements(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.database = conn.Session().Database
+
+	if err := c.recoverXA(ctx, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
 	return conn, nil
 }
 
 // Close implements the driver.Conn interface.
 func (c *Conn) Close() error {
-	if err := c.Conn.Close(); err != nil {
+	if c.stmtCache != nil {
+		c.stmtCache.clear()
+	}
+
+	err := c.Conn.Close()
+	c.logger.logResult(context.Background(), LogLevelInfo, "close", err)
+	if err != nil {
 		return fmt.Errorf("go-ase: error closing TDS connection: %w", err)
 	}
 
@@ -122,12 +275,19 @@ func (c *Conn) Close() error {
 
 // ExecContext implements the driver.ExecerContext.
 func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
+	ctx, end := c.traceQuery(ctx, query, args)
+
 	rows, result, err := c.GenericExec(ctx, query, args)
 
 	if rows != nil {
 		rows.Close()
 	}
 
+	end(result, err)
 	return result, err
 }
 
@@ -141,6 +301,24 @@ type NoQueryCursor bool
 // If the context has NoQueryCursor set it overrides
 // c.Info.NoQueryCursor.
 func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
+	ctx, end := c.traceQuery(ctx, query, args)
+
+	rows, err := c.queryContext(ctx, query, args)
+
+	end(nil, err)
+	return rows, err
+}
+
+func (c *Conn) queryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, query, err := withStatementIsolation(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	noQueryCursor := c.Info.NoQueryCursor
 
 	ctxNoQueryCursor, ok := ctx.Value(NoQueryCursor(true)).(bool)
@@ -162,7 +340,7 @@ func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 }
 
 // Ping implements the driver.Pinger interface.
-func (c Conn) Ping(ctx context.Context) error {
+func (c *Conn) Ping(ctx context.Context) error {
 	// TODO implement ErrBadConn check
 	rows, _, err := c.language(ctx, "select 'ping'")
 	if err != nil {
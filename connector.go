@@ -6,8 +6,10 @@ package ase
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/SAP/go-dblib/tds"
 )
@@ -22,6 +24,148 @@ type Connector struct {
 	Info           *Info
 	EnvChangeHooks []tds.EnvChangeHook
 	EEDHooks       []tds.EEDHook
+
+	// CredentialsProvider is consulted for the password on every
+	// Connect. If it is nil Info.Password is used.
+	CredentialsProvider CredentialsProvider
+
+	// InitStatements are executed after every new connection has
+	// been established and switched to Info.Database, e.g. to set
+	// session options. Info.InitSQL is executed before InitStatements.
+	//
+	// The statements are executed again when database/sql resets the
+	// session of a pooled connection.
+	InitStatements []string
+
+	// Dialer is used to establish the network connection to the server
+	// if set. The address passed to the dialer is built from Info.Host
+	// and Info.Port, TLS is negotiated on top of the returned
+	// connection.
+	// If Info.Proxy is set the dialer is used to connect to the proxy.
+	Dialer Dialer
+
+	// TLSConfig overrides the TLS configuration derived from Info and
+	// enables TLS if set. If TLSConfig.ServerName is empty Info.Host is
+	// used.
+	TLSConfig *tls.Config
+
+	// Recorder records the TDS messages of the connections if set, see
+	// WithRecorder.
+	Recorder *Recorder
+
+	// Tracer receives events at the boundaries of database calls if
+	// set.
+	Tracer Tracer
+
+	// Logger receives the log entries of the driver with at least
+	// LogLevel if set.
+	Logger   Logger
+	LogLevel LogLevel
+	// LoggedParameters are the names or ordinals of parameters whose
+	// values are logged. The values of all other parameters are
+	// redacted.
+	LoggedParameters []string
+
+	// MultipleResultSets permits commands on a connection while rows
+	// are open, see WithMultipleResultSets.
+	MultipleResultSets bool
+	// MaxBufferedRows is the maximum number of rows of a response read
+	// into memory for MultipleResultSets, see WithMaxBufferedRows.
+	// Defaults to DefaultMaxBufferedRows if zero.
+	MaxBufferedRows int
+
+	// StmtCacheSize is the number of statements each connection keeps
+	// prepared, see WithStmtCacheSize. Defaults to DefaultStmtCacheSize
+	// if zero.
+	StmtCacheSize int
+
+	// XARecoveryHandler is called with the in-doubt transaction
+	// branches after the first connection of the connector has been
+	// established, see WithXARecovery.
+	XARecoveryHandler XARecoveryHandler
+
+	// driver is the driver whose hooks are called by connections of
+	// the connector, the driver registered as DriverName if nil.
+	driver *Driver
+
+	// xaRecovery records whether XARecoveryHandler completed, it is
+	// shared by the copies of the connector.
+	xaRecovery *xaRecovery
+
+	// skipValidation is set by WithoutValidation.
+	skipValidation bool
+	// disableStmtCache is set by WithoutStmtCache.
+	disableStmtCache bool
+
+	// allowExpiredPassword permits logins with expired passwords, which
+	// is required to change the password.
+	allowExpiredPassword bool
+}
+
+// ConnectorOption configures a Connector created by
+// NewConnectorWithOptions.
+type ConnectorOption func(*Connector) error
+
+// WithEnvChangeHooks adds EnvChangeHooks to the connector.
+func WithEnvChangeHooks(hooks ...tds.EnvChangeHook) ConnectorOption {
+	return func(c *Connector) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return fmt.Errorf("go-ase: received nil EnvChangeHook: %#v", hooks)
+			}
+		}
+
+		c.EnvChangeHooks = append(c.EnvChangeHooks, hooks...)
+		return nil
+	}
+}
+
+// WithEEDHooks adds EEDHooks to the connector.
+func WithEEDHooks(hooks ...tds.EEDHook) ConnectorOption {
+	return func(c *Connector) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return fmt.Errorf("go-ase: received nil EEDHook: %#v", hooks)
+			}
+		}
+
+		c.EEDHooks = append(c.EEDHooks, hooks...)
+		return nil
+	}
+}
+
+// WithDialer sets the Dialer of the connector.
+func WithDialer(dialer Dialer) ConnectorOption {
+	return func(c *Connector) error {
+		c.Dialer = dialer
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the connector.
+func WithTLSConfig(tlsConfig *tls.Config) ConnectorOption {
+	return func(c *Connector) error {
+		c.TLSConfig = tlsConfig
+		return nil
+	}
+}
+
+// WithCredentialsProvider sets the CredentialsProvider of the connector.
+func WithCredentialsProvider(provider CredentialsProvider) ConnectorOption {
+	return func(c *Connector) error {
+		c.CredentialsProvider = provider
+		return nil
+	}
+}
+
+// WithoutValidation prevents NewConnectorWithOptions from opening
+// a test connection. Errors in the configuration are reported when the
+// first connection is opened instead.
+func WithoutValidation() ConnectorOption {
+	return func(c *Connector) error {
+		c.skipValidation = true
+		return nil
+	}
 }
 
 // NewConnector returns a new connector with the passed configuration.
@@ -32,33 +176,122 @@ func NewConnector(info *Info) (driver.Connector, error) {
 // NewConnectorWithHooks returns a new connector with the passed
 // configuration.
 func NewConnectorWithHooks(info *Info, envChangeHooks []tds.EnvChangeHook, eedHooks []tds.EEDHook) (driver.Connector, error) {
+	connector, err := NewConnectorWithOptions(context.Background(), info,
+		WithEnvChangeHooks(envChangeHooks...),
+		WithEEDHooks(eedHooks...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector, nil
+}
+
+// NewConnectorWithOptions returns a new connector with the passed
+// configuration and options applied.
+//
+// Unless info.NoConnectorValidation is set or WithoutValidation is
+// passed a test connection is opened and closed with the passed
+// context to validate the configuration.
+func NewConnectorWithOptions(ctx context.Context, info *Info, opts ...ConnectorOption) (*Connector, error) {
 	connector := &Connector{
-		Info: info,
+		Info:       info,
+		xaRecovery: &xaRecovery{},
 	}
 
-	conn, err := connector.Connect(context.Background())
-	if err != nil {
-		return nil, fmt.Errorf("error opening test connection: %w", err)
+	for _, opt := range opts {
+		if err := opt(connector); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := conn.Close(); err != nil {
-		return nil, fmt.Errorf("error closing test connection: %w", err)
+	if connector.skipValidation || info.NoConnectorValidation {
+		return connector, nil
 	}
 
-	// Set the hooks after validating the connection otherwise hooks
-	// would get called during the test connection.
-	connector.EnvChangeHooks = envChangeHooks
-	connector.EEDHooks = eedHooks
+	if err := connector.Validate(ctx); err != nil {
+		return nil, err
+	}
 
 	return connector, nil
 }
 
+// Validate opens and closes a test connection with the configuration
+// of the connector.
+//
+// Hooks of the connector are not called for the test connection.
+func (c *Connector) Validate(ctx context.Context) error {
+	validator := *c
+	validator.EnvChangeHooks = nil
+	validator.EEDHooks = nil
+
+	conn, err := validator.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("error opening test connection: %w", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("error closing test connection: %w", err)
+	}
+
+	return nil
+}
+
 // Driver implements the driver.Connector interface.
 func (c Connector) Driver() driver.Driver {
-	return drv
+	return c.drv()
+}
+
+// drv returns the driver of the connector.
+func (c Connector) drv() *Driver {
+	if c.driver == nil {
+		return drv
+	}
+	return c.driver
+}
+
+// withDriver sets the driver of the connector.
+func withDriver(d *Driver) ConnectorOption {
+	return func(c *Connector) error {
+		c.driver = d
+		return nil
+	}
 }
 
 // Connect implements the driver.Connector interface.
+//
+// If Info.ConnectTimeout is set the connection must be established
+// within the timeout.
 func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
-	return NewConnWithHooks(ctx, c.Info, c.EnvChangeHooks, c.EEDHooks)
+	if c.Info.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.Info.ConnectTimeout)*time.Second)
+		defer cancel()
+	}
+
+	if c.CredentialsProvider == nil {
+		return c.newConn(ctx, c.Info.Password)
+	}
+
+	conn, err := c.connectWithCredentials(ctx, false)
+	if err != nil && hasMsgNumber(err, msgLoginFailed) {
+		// The password may have been rotated since it was last read,
+		// retry once with a refreshed password.
+		conn, err = c.connectWithCredentials(ctx, true)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *Connector) connectWithCredentials(ctx context.Context, refresh bool) (*Conn, error) {
+	password, err := c.CredentialsProvider.Password(ctx, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error retrieving password from credentials provider: %w", err)
+	}
+
+	return c.newConn(ctx, password)
 }
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// blockingDialer blocks until the context is done and counts the
+// number of dials.
+type blockingDialer struct {
+	dials int
+}
+
+func (d *blockingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.dials++
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestNewConnectorWithOptionsWithoutValidation(t *testing.T) {
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+
+	dialer := &blockingDialer{}
+	if _, err := NewConnectorWithOptions(context.Background(), info, WithDialer(dialer), WithoutValidation()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dialer.dials != 0 {
+		t.Errorf("expected no test connection, got %d dials", dialer.dials)
+	}
+
+	info.NoConnectorValidation = true
+	if _, err := NewConnectorWithOptions(context.Background(), info, WithDialer(dialer)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dialer.dials != 0 {
+		t.Errorf("expected no test connection, got %d dials", dialer.dials)
+	}
+}
+
+func TestNewConnectorWithOptionsValidationContext(t *testing.T) {
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	dialer := &blockingDialer{}
+	_, err = NewConnectorWithOptions(ctx, info, WithDialer(dialer))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded, got: %v", err)
+	}
+
+	if dialer.dials != 1 {
+		t.Errorf("expected one test connection, got %d dials", dialer.dials)
+	}
+}
+
+func TestConnectorConnectTimeout(t *testing.T) {
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.ConnectTimeout = 1
+
+	connector, err := NewConnectorWithOptions(context.Background(), info, WithDialer(&blockingDialer{}), WithoutValidation())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded, got: %v", err)
+	}
+}
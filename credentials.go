@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CredentialsProvider supplies the password used to log in to ASE.
+//
+// Password is called by Connector.Connect for every new connection.
+// If the server rejects the login with message 4002 (login failed)
+// Password is called a second time with refresh set to true, allowing
+// providers to bypass any caches they may keep.
+type CredentialsProvider interface {
+	Password(ctx context.Context, refresh bool) (string, error)
+}
+
+// PasswordFunc is an adapter to allow the use of ordinary functions as
+// CredentialsProvider.
+type PasswordFunc func(ctx context.Context, refresh bool) (string, error)
+
+// Password implements the CredentialsProvider interface.
+func (fn PasswordFunc) Password(ctx context.Context, refresh bool) (string, error) {
+	return fn(ctx, refresh)
+}
+
+// PasswordFromFile returns a CredentialsProvider that reads the
+// password from the file at path every time a connection is opened.
+//
+// Trailing line breaks are removed from the file content.
+func PasswordFromFile(path string) CredentialsProvider {
+	return PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("go-ase: error reading password file: %w", err)
+		}
+
+		return strings.TrimRight(string(bs), "\r\n"), nil
+	})
+}
+
+// PasswordFromEnv returns a CredentialsProvider that reads the password
+// from the environment variable name every time a connection is
+// opened.
+func PasswordFromEnv(name string) CredentialsProvider {
+	return PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		password, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("go-ase: environment variable %q is not set", name)
+		}
+
+		return password, nil
+	})
+}
@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// newPasswordServer returns a server accepting logins with password,
+// rejecting other logins with message 4002. The returned function
+// returns the passwords of the received logins.
+func newPasswordServer(t *testing.T, password string) (*testServer, func() []string) {
+	t.Helper()
+
+	server := newTestServer(t)
+
+	var passwords []string
+	server.HandleLogin(func(login asetest.Login) []asetest.Message {
+		passwords = append(passwords, login.Password)
+		if login.Password != password {
+			return []asetest.Message{{Number: msgLoginFailed, Severity: 14, Text: "Login failed."}}
+		}
+		return nil
+	})
+
+	return server, func() []string { return passwords }
+}
+
+// connectWithTimeout opens a connection with connector and closes it.
+func connectWithTimeout(connector *Connector) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func TestPasswordFunc(t *testing.T) {
+	server, passwords := newPasswordServer(t, "secret")
+
+	var refreshes []bool
+	connector := server.connector(WithCredentialsProvider(PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		refreshes = append(refreshes, refresh)
+		return "secret", nil
+	})))
+
+	for i := 0; i < 2; i++ {
+		if err := connectWithTimeout(connector); err != nil {
+			t.Fatalf("error connecting: %v", err)
+		}
+	}
+
+	// The provider is consulted for every connection.
+	if !reflect.DeepEqual(refreshes, []bool{false, false}) {
+		t.Errorf("unexpected calls of provider: %v", refreshes)
+	}
+
+	if !reflect.DeepEqual(passwords(), []string{"secret", "secret"}) {
+		t.Errorf("unexpected passwords: %q", passwords())
+	}
+
+	// The password is not set on the shared info.
+	if connector.Info.Password != "" {
+		t.Errorf("password leaked into info: %q", connector.Info.Password)
+	}
+}
+
+func TestCredentialsProviderRefresh(t *testing.T) {
+	server, passwords := newPasswordServer(t, "rotated")
+
+	var refreshes []bool
+	connector := server.connector(WithCredentialsProvider(PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		refreshes = append(refreshes, refresh)
+		if refresh {
+			return "rotated", nil
+		}
+		return "cached", nil
+	})))
+
+	if err := connectWithTimeout(connector); err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+
+	if !reflect.DeepEqual(refreshes, []bool{false, true}) {
+		t.Errorf("unexpected calls of provider: %v", refreshes)
+	}
+
+	if !reflect.DeepEqual(passwords(), []string{"cached", "rotated"}) {
+		t.Errorf("unexpected passwords: %q", passwords())
+	}
+}
+
+func TestCredentialsProviderRefreshFails(t *testing.T) {
+	server, passwords := newPasswordServer(t, "secret")
+
+	connector := server.connector(WithCredentialsProvider(PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		return "wrong", nil
+	})))
+
+	err := connectWithTimeout(connector)
+	if number, ok := MessageNumber(err); !ok || number != msgLoginFailed {
+		t.Errorf("expected login to fail with message %d, got: %v", msgLoginFailed, err)
+	}
+
+	// The login is only retried once.
+	if !reflect.DeepEqual(passwords(), []string{"wrong", "wrong"}) {
+		t.Errorf("unexpected passwords: %q", passwords())
+	}
+}
+
+func TestCredentialsProviderError(t *testing.T) {
+	server, passwords := newPasswordServer(t, "secret")
+
+	errProvider := errors.New("provider unavailable")
+	connector := server.connector(WithCredentialsProvider(PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		return "", errProvider
+	})))
+
+	if err := connectWithTimeout(connector); !errors.Is(err, errProvider) {
+		t.Errorf("expected error of provider, got: %v", err)
+	}
+
+	if len(passwords()) != 0 {
+		t.Errorf("unexpected logins with passwords: %q", passwords())
+	}
+}
+
+func TestPasswordFromFile(t *testing.T) {
+	server, passwords := newPasswordServer(t, "secret")
+
+	path := filepath.Join(t.TempDir(), "password")
+	connector := server.connector(WithCredentialsProvider(PasswordFromFile(path)))
+
+	if err := connectWithTimeout(connector); err == nil {
+		t.Errorf("expected error connecting without password file")
+	}
+
+	if err := os.WriteFile(path, []byte("secret\r\n"), 0o600); err != nil {
+		t.Fatalf("error writing password file: %v", err)
+	}
+
+	if err := connectWithTimeout(connector); err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+
+	if !reflect.DeepEqual(passwords(), []string{"secret"}) {
+		t.Errorf("unexpected passwords: %q", passwords())
+	}
+}
+
+func TestPasswordFromEnv(t *testing.T) {
+	server, passwords := newPasswordServer(t, "secret")
+
+	const name = "GO_ASE_TEST_PASSWORD"
+	connector := server.connector(WithCredentialsProvider(PasswordFromEnv(name)))
+
+	// t.Setenv restores the variable when the test finishes.
+	t.Setenv(name, "")
+	os.Unsetenv(name)
+	if err := connectWithTimeout(connector); err == nil {
+		t.Errorf("expected error connecting without environment variable")
+	}
+
+	t.Setenv(name, "secret")
+
+	if err := connectWithTimeout(connector); err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+
+	if !reflect.DeepEqual(passwords(), []string{"secret"}) {
+		t.Errorf("unexpected passwords: %q", passwords())
+	}
+}
+
+func TestPasswordFuncProvider(t *testing.T) {
+	var refreshes []bool
+	provider := PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+		refreshes = append(refreshes, refresh)
+		return "secret", nil
+	})
+
+	for _, refresh := range []bool{false, true} {
+		password, err := provider.Password(context.Background(), refresh)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if password != "secret" {
+			t.Errorf("expected password %q, got %q", "secret", password)
+		}
+	}
+
+	if len(refreshes) != 2 || refreshes[0] || !refreshes[1] {
+		t.Errorf("unexpected refresh arguments: %v", refreshes)
+	}
+}
+
+func TestPasswordFromFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	provider := PasswordFromFile(path)
+
+	if _, err := provider.Password(context.Background(), false); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected error reading missing file, got: %v", err)
+	}
+
+	// The file is read on every call and trailing line breaks are
+	// removed.
+	for content, expected := range map[string]string{
+		"secret\n":    "secret",
+		"rotated\r\n": "rotated",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("error writing password file: %v", err)
+		}
+
+		password, err := provider.Password(context.Background(), false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if password != expected {
+			t.Errorf("expected password %q for file content %q, got %q", expected, content, password)
+		}
+	}
+}
+
+func TestPasswordFromEnvProvider(t *testing.T) {
+	const name = "GO_ASE_TEST_PROVIDER_PASSWORD"
+	provider := PasswordFromEnv(name)
+
+	// t.Setenv restores the variable when the test finishes.
+	t.Setenv(name, "")
+	os.Unsetenv(name)
+	if _, err := provider.Password(context.Background(), false); err == nil {
+		t.Errorf("expected error without environment variable")
+	}
+
+	t.Setenv(name, "secret")
+	password, err := provider.Password(context.Background(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password != "secret" {
+		t.Errorf("expected password %q, got %q", "secret", password)
+	}
+}
+
+func TestHasMsgNumber(t *testing.T) {
+	eedError := &tds.EEDError{
+		EEDPackages: []*tds.EEDPackage{{MsgNumber: 5701}, {MsgNumber: msgLoginFailed}},
+	}
+	err := fmt.Errorf("go-ase: error logging in: %w", eedError)
+
+	if !hasMsgNumber(err, msgLoginFailed) {
+		t.Errorf("expected message %d to be found in %v", msgLoginFailed, err)
+	}
+
+	if hasMsgNumber(err, 4022) {
+		t.Errorf("unexpected message 4022 found in %v", err)
+	}
+
+	if hasMsgNumber(errors.New("login failed"), msgLoginFailed) {
+		t.Errorf("unexpected message found in error without EEDError")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/SAP/go-dblib/namepool"
 	"github.com/SAP/go-dblib/tds"
@@ -36,6 +37,9 @@ type Cursor struct {
 
 	stmt *Stmt
 
+	// handler receives the messages of fetches from the cursor.
+	handler MessageHandler
+
 	paramFmt *tds.ParamFmtPackage
 	rowFmt   *tds.RowFmtPackage
 
@@ -66,10 +70,29 @@ func (c *Conn) NewCursor(ctx context.Context, query string, args ...interface{})
 
 // NewCursorWithValues creates a new cursor.
 func (c *Conn) NewCursorWithValues(ctx context.Context, query string, args []driver.NamedValue) (*Cursor, error) {
+	if err := c.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
+	ctx, query, err := withStatementIsolation(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error allocating cursor on server: %w", err)
+	}
+
 	cursor := new(Cursor)
 	cursor.conn = c
-
-	if err := cursor.allocateOnServer(ctx, query, args); err != nil {
+	cursor.handler = messageHandler(ctx)
+
+	start := time.Now()
+	err = cursor.allocateOnServer(ctx, query, args)
+	c.tracer.CursorOpen(ctx, CursorEvent{
+		Name:     cursor.poolName.String(),
+		Query:    query,
+		Args:     len(args),
+		Err:      err,
+		Duration: time.Since(start),
+	})
+	if err != nil {
 		return nil, fmt.Errorf("go-ase: error allocating cursor on server: %w", err)
 	}
 
@@ -103,8 +126,18 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		cursorQuery = cursor.poolName.String()
 	}
 
+	return cursor.open(ctx, cursorQuery, args)
+}
+
+// open declares and opens the cursor on the server.
+func (cursor *Cursor) open(ctx context.Context, query string, args []driver.NamedValue) error {
+	if err := cursor.conn.acquire(ctx); err != nil {
+		return err
+	}
+	defer cursor.conn.release()
+
 	// Declare cursor.
-	declarePkg, err := tds.NewCurDeclarePackage(cursor.poolName.String(), cursorQuery,
+	declarePkg, err := tds.NewCurDeclarePackage(cursor.poolName.String(), query,
 		tds.TDS_CUR_DSTAT_UNUSED,
 		tds.TDS_CUR_DOPT_UNUSED,
 	)
@@ -115,11 +148,12 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		declarePkg.Options |= tds.TDS_CUR_DOPT_DYNAMIC
 	}
 
-	if err := cursor.conn.Channel.SendPackage(ctx, declarePkg); err != nil {
+	cursor.conn.stats.roundTrip()
+	if err := cursor.conn.channel.SendPackage(ctx, declarePkg); err != nil {
 		return fmt.Errorf("error sending CurDeclarePackage: %w", err)
 	}
 
-	_, err = cursor.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+	_, err = cursor.conn.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
 		switch typed := pkg.(type) {
 		case *tds.DynamicPackage:
 			if typed.Type&tds.TDS_DYN_ACK != tds.TDS_DYN_ACK {
@@ -145,12 +179,13 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 			cursor.paramFmt = typed
 			return false, nil
 		case *tds.DonePackage:
-			ok, err := handleDonePackage(typed)
+			ok, err := cursor.conn.handleDonePackage(typed)
 			if err != nil {
 				return true, err
 			}
 			return ok, nil
 		default:
+			cursor.conn.logUnhandledPackage(ctx, "cursor declare", typed)
 			return true, fmt.Errorf("unhandled package type %T: %s", typed, typed)
 		}
 	})
@@ -169,11 +204,12 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		RowCount:  int32(cursor.conn.Info.CursorCacheRows),
 	}
 
-	if err := cursor.conn.Channel.SendPackage(ctx, setFetchCount); err != nil {
+	cursor.conn.stats.roundTrip()
+	if err := cursor.conn.channel.SendPackage(ctx, setFetchCount); err != nil {
 		return fmt.Errorf("error queueing CurInfoPackage to set fetch row count: %w", err)
 	}
 
-	_, err = cursor.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+	_, err = cursor.conn.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
 		switch typed := pkg.(type) {
 		case *tds.DynamicPackage:
 			if typed.Type&tds.TDS_DYN_ACK != tds.TDS_DYN_ACK {
@@ -199,12 +235,13 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 			cursor.paramFmt = typed
 			return false, nil
 		case *tds.DonePackage:
-			ok, err := handleDonePackage(typed)
+			ok, err := cursor.conn.handleDonePackage(typed)
 			if err != nil {
 				return true, err
 			}
 			return ok, nil
 		default:
+			cursor.conn.logUnhandledPackage(ctx, "cursor declare", typed)
 			return true, fmt.Errorf("unhandled package type %T: %s", typed, typed)
 		}
 	})
@@ -222,7 +259,7 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		openPkg.Status = tds.TDS_CUR_OSTAT_HASARGS
 	}
 
-	if err := cursor.conn.Channel.QueuePackage(ctx, openPkg); err != nil {
+	if err := cursor.conn.channel.QueuePackage(ctx, openPkg); err != nil {
 		return fmt.Errorf("error queueing and sending CurOpenPackage: %w", err)
 	}
 
@@ -232,11 +269,12 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		}
 	}
 
-	if err := cursor.conn.Channel.SendRemainingPackets(ctx); err != nil {
+	cursor.conn.stats.roundTrip()
+	if err := cursor.conn.channel.SendRemainingPackets(ctx); err != nil {
 		return fmt.Errorf("error sending packages: %w", err)
 	}
 
-	_, err = cursor.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+	_, err = cursor.conn.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
 		switch typed := pkg.(type) {
 		case *tds.CurInfoPackage:
 			if typed.Command != tds.TDS_CUR_CMD_INFORM {
@@ -263,12 +301,13 @@ func (cursor *Cursor) allocateOnServer(ctx context.Context, query string, args [
 		case *tds.OrderByPackage, *tds.OrderBy2Package:
 			return false, nil
 		case *tds.DonePackage:
-			ok, err := handleDonePackage(typed)
+			ok, err := cursor.conn.handleDonePackage(typed)
 			if err != nil {
 				return true, fmt.Errorf("go-ase: %w", err)
 			}
 			return ok, nil
 		default:
+			cursor.conn.logUnhandledPackage(ctx, "cursor open", typed)
 			return true, fmt.Errorf("unhandled package type %T: %v", typed, typed)
 		}
 	})
@@ -292,6 +331,17 @@ func (cursor *Cursor) Close(ctx context.Context) error {
 		return nil
 	}
 
+	start := time.Now()
+	err := cursor.close(ctx)
+	cursor.conn.tracer.CursorClose(ctx, CursorEvent{
+		Name:     cursor.poolName.String(),
+		Err:      err,
+		Duration: time.Since(start),
+	})
+	return err
+}
+
+func (cursor *Cursor) close(ctx context.Context) error {
 	if cursor.hasArgs {
 		// cursor has an associated prepared statement
 		if err := cursor.stmt.Close(); err != nil {
@@ -305,13 +355,24 @@ func (cursor *Cursor) Close(ctx context.Context) error {
 		}
 	}
 
+	return cursor.deallocate(ctx)
+}
+
+// deallocate closes and deallocates the cursor on the server.
+func (cursor *Cursor) deallocate(ctx context.Context) error {
+	if err := cursor.conn.acquire(ctx); err != nil {
+		return err
+	}
+	defer cursor.conn.release()
+
 	closePkg := &tds.CurClosePackage{
 		CursorID: cursor.cursorID,
 		Name:     cursor.name,
 		Options:  tds.TDS_CUR_COPT_DEALLOC,
 	}
 
-	if err := cursor.conn.Channel.SendPackage(ctx, closePkg); err != nil {
+	cursor.conn.stats.roundTrip()
+	if err := cursor.conn.channel.SendPackage(ctx, closePkg); err != nil {
 		return fmt.Errorf("go-ase: error sending CurClosePackage: %w", err)
 	}
 
@@ -344,39 +405,23 @@ func (cursor *Cursor) Close(ctx context.Context) error {
 func (cursor *Cursor) closeReadResponse(ctx context.Context) (bool, error) {
 	rxCurDealloc := false
 
-	_, err := cursor.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
-		switch typed := pkg.(type) {
-		case *tds.CurInfoPackage:
-			if typed.Command != tds.TDS_CUR_CMD_INFORM {
-				return true, fmt.Errorf("go-ase: received %T with command %s instead of TDS_CUR_CMD_INFORM",
-					typed, typed.Command)
-			}
-
-			if typed.Status&tds.TDS_CUR_ISTAT_CLOSED != tds.TDS_CUR_ISTAT_CLOSED &&
-				typed.Status&tds.TDS_CUR_ISTAT_DEALLOC != tds.TDS_CUR_ISTAT_DEALLOC {
+	_, err := cursor.conn.readStream(ctx, true, resultStream{
+		operation: "cursor close",
+		// TDS sends an empty TDS_DONE_COUNT before TDS_DONE_FINAL
+		done: doneCursor,
+		curInfo: func(pkg *tds.CurInfoPackage) (bool, error) {
+			if pkg.Status&tds.TDS_CUR_ISTAT_CLOSED != tds.TDS_CUR_ISTAT_CLOSED &&
+				pkg.Status&tds.TDS_CUR_ISTAT_DEALLOC != tds.TDS_CUR_ISTAT_DEALLOC {
 				return true, fmt.Errorf("go-ase: received %T without status TDS_CUR_ISTAT_CLOSED or TDS_CUR_ISTAT_DEALLOC",
-					typed)
+					pkg)
 			}
 
-			if typed.Status&tds.TDS_CUR_ISTAT_DEALLOC == tds.TDS_CUR_ISTAT_DEALLOC {
+			if pkg.Status&tds.TDS_CUR_ISTAT_DEALLOC == tds.TDS_CUR_ISTAT_DEALLOC {
 				rxCurDealloc = true
 			}
 
 			return false, nil
-		case *tds.DonePackage:
-			// TDS sends an empty TDS_DONE_COUNT before TDS_DONE_FINAL
-			if typed.Status == tds.TDS_DONE_COUNT {
-				return false, nil
-			}
-
-			ok, err := handleDonePackage(typed)
-			if err != nil {
-				return true, err
-			}
-			return ok, nil
-		default:
-			return true, fmt.Errorf("go-ase: unhandled package type %T: %v", typed, typed)
-		}
+		},
 	})
 	if err != nil && !errors.Is(err, io.EOF) {
 		return rxCurDealloc, err
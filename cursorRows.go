@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/SAP/go-dblib/tds"
 )
@@ -28,7 +29,9 @@ var (
 // CursorRows is used to iterate over the result set of a cursor.
 type CursorRows struct {
 	cursor *Cursor
-	rows   chan *tds.RowPackage
+	// rows are the fetched rows that have not been read yet. A fetch
+	// returns up to Info.CursorCacheRows rows.
+	rows []*tds.RowPackage
 
 	baseRows
 
@@ -47,8 +50,6 @@ func (cursor *Cursor) NewCursorRows() (*CursorRows, error) {
 	cursorRows.cursor = cursor
 	cursorRows.rowFmt = func() *tds.RowFmtPackage { return cursor.rowFmt }
 
-	cursorRows.rows = make(chan *tds.RowPackage, cursor.conn.Info.CursorCacheRows)
-
 	return cursorRows, nil
 }
 
@@ -68,25 +69,34 @@ func (rows *CursorRows) Next(dst []driver.Value) error {
 		return fmt.Errorf("go-ase: error getting next row: %w", err)
 	}
 
+	if len(dst) != len(rowPkg.DataFields) {
+		return fmt.Errorf("go-ase: received invalid number of destinations, expecting %d destinations, got %d", len(rowPkg.DataFields), len(dst))
+	}
+
 	for i := range dst {
 		dst[i] = rowPkg.DataFields[i].Value()
 	}
 	rows.readRows++
+	rows.cursor.conn.stats.cursorRowConsumed()
 
 	return nil
 }
 
-// nextPkg is a wrapper to handle reading from the rows channel and
-// fetching new rows as needed.
+// nextPkg is a wrapper to handle reading the fetched rows and fetching
+// new rows as needed.
 func (rows *CursorRows) nextPkg(ctx context.Context) (*tds.RowPackage, error) {
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case rowPkg, ok := <-rows.rows:
-		if ok {
-			return rowPkg, nil
-		}
-	default:
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rows.rows) > 0 {
+		rowPkg := rows.rows[0]
+		rows.rows = rows.rows[1:]
+		return rowPkg, nil
+	}
+
+	if rows.isClosed() {
+		return nil, ErrCurNoMoreRows
 	}
 
 	// fetch more rows
@@ -99,17 +109,43 @@ func (rows *CursorRows) nextPkg(ctx context.Context) (*tds.RowPackage, error) {
 
 // fetch retrieves the next part of the result set from the ASE server.
 func (rows *CursorRows) fetch(ctx context.Context) error {
+	start := time.Now()
+	buffered := len(rows.rows)
+
+	err := rows.fetchRows(ctx)
+
+	event := CursorFetchEvent{
+		Name:     rows.cursor.poolName.String(),
+		Rows:     len(rows.rows) - buffered,
+		Duration: time.Since(start),
+	}
+	// An exhausted result set is not an error of the fetch.
+	if !errors.Is(err, ErrCurNoMoreRows) {
+		event.Err = err
+	}
+	rows.cursor.conn.tracer.CursorFetch(ctx, event)
+
+	return err
+}
+
+func (rows *CursorRows) fetchRows(ctx context.Context) error {
+	if err := rows.cursor.conn.acquireHandler(ctx, rows.cursor.handler); err != nil {
+		return err
+	}
+	defer rows.cursor.conn.release()
+
 	// Set the last received package to the rowfmt received during
 	// setup. The params/rows packages need the information from the
 	// format to setup the data fields.
-	rows.cursor.conn.Channel.SetLastPkgRx(rows.cursor.rowFmt)
+	rows.cursor.conn.channel.SetLastPkgRx(rows.cursor.rowFmt)
 
 	fetchPkg := &tds.CurFetchPackage{
 		CursorID: rows.cursor.cursorID,
 		Name:     rows.cursor.name,
 		Type:     tds.TDS_CUR_NEXT,
 	}
-	if err := rows.cursor.conn.Channel.SendPackage(ctx, fetchPkg); err != nil {
+	rows.cursor.conn.stats.roundTrip()
+	if err := rows.cursor.conn.channel.SendPackage(ctx, fetchPkg); err != nil {
 		return fmt.Errorf("error sending CurFetchPackage: %w", err)
 	}
 
@@ -127,81 +163,51 @@ func (rows *CursorRows) fetch(ctx context.Context) error {
 	// return a row.
 	//
 	// So - instead of relying on information from ASE this boolean is
-	// set when more rows are queued in the channel. If no more rows are
+	// set when more rows are buffered. If no more rows are
 	// received this function returns ErrCurNoMoreRows, signaling the
 	// cursor finished the result set.
 	readMoreRows := false
 
-	_, err := rows.cursor.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
-		switch typed := pkg.(type) {
-		case *tds.RowPackage:
-			rows.rows <- typed
+	_, err := rows.cursor.conn.readStream(ctx, true, resultStream{
+		operation: "cursor fetch",
+		done:      doneCursor,
+		count:     func(count int32) { rows.totalRows += int(count) },
+		row: func(pkg *tds.RowPackage) (bool, error) {
+			rows.rows = append(rows.rows, pkg)
 			readMoreRows = true
 			return false, nil
-		case *tds.RowFmtPackage:
+		},
+		rowFmt: func(pkg *tds.RowFmtPackage) (bool, error) {
 			// TODO: should next return io.EOF if the result set is
 			// finished?
-			rows.cursor.rowFmt = typed
+			rows.cursor.rowFmt = pkg
 			return false, nil
-		case *tds.OrderByPackage:
-			return false, nil
-		case *tds.CurInfoPackage:
+		},
+		curInfo: func(pkg *tds.CurInfoPackage) (bool, error) {
 			// When the result set is exhausted the TDS server
 			// deallocates the cursor and notifies the client using
 			// tow CurInfoPackages with TDS_CUR_ISTAT_CLOSED and
 			// TDS_CUR_ISTAT_DEALLOC.
-			if typed.Command != tds.TDS_CUR_CMD_INFORM {
-				return true, fmt.Errorf("go-ase: received %T with command %s instead of TDS_CUR_CMD_INFORM",
-					typed, typed.Command)
-			}
-
-			if typed.Status&tds.TDS_CUR_ISTAT_CLOSED == tds.TDS_CUR_ISTAT_CLOSED {
+			// The response continues with its DonePackages, which
+			// must be consumed as well.
+			if pkg.Status&tds.TDS_CUR_ISTAT_CLOSED == tds.TDS_CUR_ISTAT_CLOSED {
 				// Mark cursor as closed
 				rows.cursor.closed = true
-				return false, nil
-			}
-
-			if typed.Status&tds.TDS_CUR_ISTAT_DEALLOC == tds.TDS_CUR_ISTAT_DEALLOC {
-				return true, nil
-			}
-
-			return false, nil
-		case *tds.DonePackage:
-			if typed.Status&tds.TDS_DONE_COUNT == tds.TDS_DONE_COUNT {
-				rows.totalRows += int(typed.Count)
-				return false, nil
 			}
 
-			ok, err := handleDonePackage(typed)
-			if err != nil {
-				return true, err
-			}
-
-			return ok, nil
-		case *tds.ReturnStatusPackage:
-			if typed.ReturnValue != 0 {
-				return true, fmt.Errorf("query failed with return status %d", typed.ReturnValue)
-			}
 			return false, nil
-		default:
-			return true, fmt.Errorf("unhandled package type %T: %v", pkg, pkg)
-		}
+		},
 	})
 
 	if readMoreRows {
 		return nil
 	}
 
-	if err != nil {
-		if !rows.isClosed() {
-			close(rows.rows)
-			rows.closed = true
-		}
-		if errors.Is(err, io.EOF) {
-			return ErrCurNoMoreRows
-		}
+	rows.closed = true
+	if err != nil && !errors.Is(err, io.EOF) {
 		return fmt.Errorf("error reading next row package: %w", err)
 	}
 
-	return nil
+	// A fetch without rows ends the result set, see above.
+	return ErrCurNoMoreRows
 }
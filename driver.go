@@ -8,7 +8,9 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/SAP/go-dblib/dsn"
 	"github.com/SAP/go-dblib/tds"
@@ -16,9 +18,9 @@ import (
 
 // Interface satisfaction checks.
 var (
-	_   driver.Driver        = (*Driver)(nil)
-	_   driver.DriverContext = (*Driver)(nil)
-	drv                      = &Driver{}
+	_   driver.Driver        = Driver{}
+	_   driver.DriverContext = Driver{}
+	drv                      = NewDriver()
 )
 
 // DriverName is the driver name to use with sql.Open for ase databases.
@@ -29,14 +31,82 @@ func init() {
 }
 
 // Driver implements the driver.Driver interface.
+//
+// The driver registered as DriverName is shared by all users of the
+// package. Libraries that require their own hooks or options register
+// a driver created with NewDriver under a custom name.
+//
+// Copies of a Driver share its options, hooks and stats. The zero value
+// opens connections without options and cannot register hooks.
 type Driver struct {
-	envChangeHooks []tds.EnvChangeHook
-	eedHooks       []tds.EEDHook
+	*driverState
+}
+
+// driverState is the state shared by the copies of a Driver.
+type driverState struct {
+	// opts are applied to every connector opened by the driver.
+	opts []ConnectorOption
+	// stats aggregates the stats of the connections of the driver.
+	stats *statsCollector
+
+	hookLock       sync.RWMutex
+	hookID         uint64
+	envChangeHooks []envChangeHookEntry
+	eedHooks       []eedHookEntry
+}
+
+type envChangeHookEntry struct {
+	id uint64
+	fn tds.EnvChangeHook
+}
+
+type eedHookEntry struct {
+	id uint64
+	fn tds.EEDHook
+}
+
+// NewDriver returns a driver applying the passed options to every
+// connector it opens, e.g. to register it with sql.Register under
+// a custom name:
+//
+//	sql.Register("ase-myapp", ase.NewDriver(ase.WithTracer(tracer)))
+//
+// Hooks registered with the driver are separate from the hooks of other
+// drivers.
+func NewDriver(opts ...ConnectorOption) *Driver {
+	return &Driver{&driverState{opts: opts, stats: newStatsCollector(driverStats)}}
+}
+
+// Stats returns the counters aggregated over all connections opened by
+// the driver.
+//
+// Connections of the zero value are only counted in DriverStats.
+func (d Driver) Stats() Stats {
+	return d.statsCollector().snapshot()
+}
+
+// statsCollector returns the collector the stats collectors of the
+// connections of the driver report to.
+func (d Driver) statsCollector() *statsCollector {
+	if d.driverState == nil {
+		return driverStats
+	}
+	return d.stats
 }
 
 // Open implements the driver.Driver interface.
+//
+// The connection is opened within the connect-timeout of the DSN, if
+// set.
 func (d Driver) Open(name string) (driver.Conn, error) {
-	connector, err := d.OpenConnector(name)
+	info, err := d.parseDSN(name)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error opening connector: %w", err)
+	}
+
+	// The connection is opened right away, validating the connector
+	// would open a second connection.
+	connector, err := d.NewConnector(context.Background(), info, WithoutValidation())
 	if err != nil {
 		return nil, fmt.Errorf("go-ase: error opening connector: %w", err)
 	}
@@ -46,6 +116,32 @@ func (d Driver) Open(name string) (driver.Conn, error) {
 
 // OpenConnector implements the driver.DriverContext interface.
 func (d Driver) OpenConnector(name string) (driver.Connector, error) {
+	info, err := d.parseDSN(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.NewConnector(context.Background(), info)
+}
+
+// NewConnector returns a connector with the passed configuration whose
+// connections use the hooks of the driver, see NewConnectorWithOptions.
+//
+// The options of the driver are applied before the passed options.
+func (d Driver) NewConnector(ctx context.Context, info *Info, opts ...ConnectorOption) (*Connector, error) {
+	var driverOpts []ConnectorOption
+	driverOpts = append(driverOpts, withDriver(&d))
+	if d.driverState != nil {
+		driverOpts = append(driverOpts, d.opts...)
+	}
+	driverOpts = append(driverOpts, opts...)
+
+	return NewConnectorWithOptions(ctx, info, driverOpts...)
+}
+
+// parseDSN returns an Info with the values of the DSN and the
+// environment.
+func (d Driver) parseDSN(name string) (*Info, error) {
 	info, err := NewInfo()
 	if err != nil {
 		return nil, err
@@ -59,31 +155,173 @@ func (d Driver) OpenConnector(name string) (driver.Connector, error) {
 		return nil, err
 	}
 
-	return NewConnector(info)
+	return info, nil
 }
 
-// AddEnvChangeHooks registers funtions as hooks. The hooks are executed
-// when the driver receives EnvChange packages.
-func AddEnvChangeHooks(fns ...tds.EnvChangeHook) error {
+// errZeroDriverHooks is returned when hooks are registered with the
+// zero value of Driver, which has no state to hold them.
+var errZeroDriverHooks = errors.New("go-ase: hooks can only be registered with drivers created by NewDriver")
+
+// HookHandle removes hooks registered with a Driver.
+type HookHandle struct {
+	once   sync.Once
+	remove func()
+}
+
+// Remove unregisters the hooks. Connections that are already open stop
+// calling the hooks as well.
+//
+// Remove may be called multiple times.
+func (handle *HookHandle) Remove() {
+	handle.once.Do(handle.remove)
+}
+
+// RegisterEnvChangeHooks registers functions as hooks executed when
+// connections opened by the driver receive EnvChange packages.
+//
+// The hooks are called in the order of their registration and apply to
+// already open connections as well.
+func (d Driver) RegisterEnvChangeHooks(fns ...tds.EnvChangeHook) (*HookHandle, error) {
 	for _, fn := range fns {
 		if fn == nil {
-			return fmt.Errorf("go-ase: Received nil EnvChangeHook: %#v", fns)
+			return nil, fmt.Errorf("go-ase: Received nil EnvChangeHook: %#v", fns)
 		}
 	}
 
-	drv.envChangeHooks = append(drv.envChangeHooks, fns...)
-	return nil
+	if d.driverState == nil {
+		return nil, errZeroDriverHooks
+	}
+
+	d.hookLock.Lock()
+	defer d.hookLock.Unlock()
+
+	ids := make(map[uint64]bool, len(fns))
+	// The slice is copied on write as hooks are called with
+	// a snapshot of it.
+	hooks := make([]envChangeHookEntry, len(d.envChangeHooks), len(d.envChangeHooks)+len(fns))
+	copy(hooks, d.envChangeHooks)
+	for _, fn := range fns {
+		d.hookID++
+		ids[d.hookID] = true
+		hooks = append(hooks, envChangeHookEntry{id: d.hookID, fn: fn})
+	}
+	d.envChangeHooks = hooks
+
+	return &HookHandle{remove: func() {
+		d.hookLock.Lock()
+		defer d.hookLock.Unlock()
+
+		hooks := make([]envChangeHookEntry, 0, len(d.envChangeHooks))
+		for _, hook := range d.envChangeHooks {
+			if !ids[hook.id] {
+				hooks = append(hooks, hook)
+			}
+		}
+		d.envChangeHooks = hooks
+	}}, nil
 }
 
-// AddEEDHooks registers functions as hooks. The hooks are executed when
-// the driver receives EED packages.
-func AddEEDHooks(fns ...tds.EEDHook) error {
+// RegisterEEDHooks registers functions as hooks executed when
+// connections opened by the driver receive EED packages.
+//
+// The hooks are called in the order of their registration and apply to
+// already open connections as well.
+func (d Driver) RegisterEEDHooks(fns ...tds.EEDHook) (*HookHandle, error) {
 	for _, fn := range fns {
 		if fn == nil {
-			return fmt.Errorf("go-ase: Received nil EEDHook: %#v", fns)
+			return nil, fmt.Errorf("go-ase: Received nil EEDHook: %#v", fns)
 		}
 	}
 
-	drv.eedHooks = append(drv.eedHooks, fns...)
-	return nil
+	if d.driverState == nil {
+		return nil, errZeroDriverHooks
+	}
+
+	d.hookLock.Lock()
+	defer d.hookLock.Unlock()
+
+	ids := make(map[uint64]bool, len(fns))
+	hooks := make([]eedHookEntry, len(d.eedHooks), len(d.eedHooks)+len(fns))
+	copy(hooks, d.eedHooks)
+	for _, fn := range fns {
+		d.hookID++
+		ids[d.hookID] = true
+		hooks = append(hooks, eedHookEntry{id: d.hookID, fn: fn})
+	}
+	d.eedHooks = hooks
+
+	return &HookHandle{remove: func() {
+		d.hookLock.Lock()
+		defer d.hookLock.Unlock()
+
+		hooks := make([]eedHookEntry, 0, len(d.eedHooks))
+		for _, hook := range d.eedHooks {
+			if !ids[hook.id] {
+				hooks = append(hooks, hook)
+			}
+		}
+		d.eedHooks = hooks
+	}}, nil
+}
+
+// envChangeHook is registered with every connection of the driver and
+// calls the currently registered EnvChangeHooks.
+func (d Driver) envChangeHook(typ tds.EnvChangeType, oldValue, newValue string) {
+	if d.driverState == nil {
+		return
+	}
+
+	d.hookLock.RLock()
+	hooks := d.envChangeHooks
+	d.hookLock.RUnlock()
+
+	for _, hook := range hooks {
+		hook.fn(typ, oldValue, newValue)
+	}
+}
+
+// eedHook is registered with every connection of the driver and calls
+// the currently registered EEDHooks.
+func (d Driver) eedHook(eed tds.EEDPackage) {
+	if d.driverState == nil {
+		return
+	}
+
+	d.hookLock.RLock()
+	hooks := d.eedHooks
+	d.hookLock.RUnlock()
+
+	for _, hook := range hooks {
+		hook.fn(eed)
+	}
+}
+
+// RegisterEnvChangeHooks registers functions as hooks with the driver
+// registered as DriverName, see Driver.RegisterEnvChangeHooks.
+func RegisterEnvChangeHooks(fns ...tds.EnvChangeHook) (*HookHandle, error) {
+	return drv.RegisterEnvChangeHooks(fns...)
+}
+
+// RegisterEEDHooks registers functions as hooks with the driver
+// registered as DriverName, see Driver.RegisterEEDHooks.
+func RegisterEEDHooks(fns ...tds.EEDHook) (*HookHandle, error) {
+	return drv.RegisterEEDHooks(fns...)
+}
+
+// AddEnvChangeHooks registers funtions as hooks. The hooks are executed
+// when the driver receives EnvChange packages.
+//
+// Use RegisterEnvChangeHooks to be able to remove the hooks.
+func AddEnvChangeHooks(fns ...tds.EnvChangeHook) error {
+	_, err := RegisterEnvChangeHooks(fns...)
+	return err
+}
+
+// AddEEDHooks registers functions as hooks. The hooks are executed when
+// the driver receives EED packages.
+//
+// Use RegisterEEDHooks to be able to remove the hooks.
+func AddEEDHooks(fns ...tds.EEDHook) error {
+	_, err := RegisterEEDHooks(fns...)
+	return err
 }
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/dsn"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// eedCounter counts the EEDs passed to its hook.
+type eedCounter struct {
+	lock  sync.Mutex
+	count int
+}
+
+func (counter *eedCounter) hook(eed tds.EEDPackage) {
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+	counter.count++
+}
+
+func (counter *eedCounter) get() int {
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+	return counter.count
+}
+
+func TestDriverHooks(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("use master", asetest.Result{
+		Messages: []asetest.Message{{Number: 5701, Text: "Changed database context to 'master'."}},
+	})
+
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+
+	d := NewDriver(WithDialer(&net.Dialer{}))
+	connector, err := d.NewConnector(context.Background(), info, WithoutValidation())
+	if err != nil {
+		t.Fatalf("error creating connector: %v", err)
+	}
+
+	if connector.Driver().(*Driver).driverState != d.driverState {
+		t.Errorf("expected connector to return its driver")
+	}
+
+	driverCounter, defaultCounter := &eedCounter{}, &eedCounter{}
+
+	handle, err := d.RegisterEEDHooks(driverCounter.hook)
+	if err != nil {
+		t.Fatalf("error registering hook: %v", err)
+	}
+
+	defaultHandle, err := RegisterEEDHooks(defaultCounter.hook)
+	if err != nil {
+		t.Fatalf("error registering hook: %v", err)
+	}
+	defer defaultHandle.Remove()
+
+	driverConn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("error opening connection: %v", err)
+	}
+	defer driverConn.Close()
+	conn := driverConn.(*Conn)
+
+	if _, err := conn.ExecContext(context.Background(), "use master", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if count := driverCounter.get(); count != 1 {
+		t.Errorf("expected hook of the driver to be called once, got %d", count)
+	}
+
+	if count := defaultCounter.get(); count != 0 {
+		t.Errorf("expected hook of the default driver not to be called, got %d", count)
+	}
+
+	handle.Remove()
+	handle.Remove()
+
+	if _, err := conn.ExecContext(context.Background(), "use master", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if count := driverCounter.get(); count != 1 {
+		t.Errorf("expected removed hook not to be called, got %d calls", count)
+	}
+}
+
+func TestDriverHooksConcurrent(t *testing.T) {
+	d := NewDriver()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			counter := &eedCounter{}
+			handle, err := d.RegisterEEDHooks(counter.hook)
+			if err != nil {
+				t.Errorf("error registering hook: %v", err)
+				return
+			}
+			d.eedHook(tds.EEDPackage{})
+			handle.Remove()
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			handle, err := d.RegisterEnvChangeHooks(func(tds.EnvChangeType, string, string) {})
+			if err != nil {
+				t.Errorf("error registering hook: %v", err)
+				return
+			}
+			d.envChangeHook(tds.TDS_ENV_DB, "old", "new")
+			handle.Remove()
+		}()
+	}
+	wg.Wait()
+
+	if len(d.eedHooks) != 0 || len(d.envChangeHooks) != 0 {
+		t.Errorf("expected all hooks to be removed, got %d and %d", len(d.eedHooks), len(d.envChangeHooks))
+	}
+}
+
+func TestDriverHooksNil(t *testing.T) {
+	if _, err := NewDriver().RegisterEEDHooks(nil); err == nil {
+		t.Errorf("expected error registering nil hook")
+	}
+}
+
+func TestDriverZeroValue(t *testing.T) {
+	server := newTestServer(t)
+
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+	info.Username, info.Password = "user", "password"
+
+	var d driver.Driver = Driver{}
+	conn, err := d.Open(dsn.FormatSimple(info))
+	if err != nil {
+		t.Fatalf("error opening connection with zero driver: %v", err)
+	}
+	conn.Close()
+
+	if _, err := (Driver{}).RegisterEEDHooks(func(tds.EEDPackage) {}); err == nil {
+		t.Errorf("expected error registering hook with zero driver")
+	}
+}
+
+func TestDriverStats(t *testing.T) {
+	server := newTestServer(t)
+
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = server.Host(), server.Port()
+
+	d := NewDriver()
+	// Copies share the stats of the driver.
+	connector, err := (*d).NewConnector(context.Background(), info, WithoutValidation())
+	if err != nil {
+		t.Fatalf("error creating connector: %v", err)
+	}
+
+	before := DriverStats().Latencies[OperationLogin].Count
+
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+	conn.Close()
+
+	if count := d.Stats().Latencies[OperationLogin].Count; count != 1 {
+		t.Errorf("expected 1 login in driver stats, got %d", count)
+	}
+
+	if count := NewDriver().Stats().Latencies[OperationLogin].Count; count != 0 {
+		t.Errorf("expected no logins in stats of other driver, got %d", count)
+	}
+
+	if count := DriverStats().Latencies[OperationLogin].Count - before; count < 1 {
+		t.Errorf("expected login in aggregated stats")
+	}
+}
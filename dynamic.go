@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/SAP/go-dblib"
 	"github.com/SAP/go-dblib/asetypes"
@@ -33,6 +34,8 @@ type Stmt struct {
 
 	stmtId *namepool.Name
 	pkg    *tds.DynamicPackage
+	// query is the prepared query.
+	query string
 
 	paramFmt *tds.ParamFmtPackage
 	rowFmt   *tds.RowFmtPackage
@@ -47,13 +50,17 @@ func (c *Conn) Prepare(query string) (driver.Stmt, error) {
 
 // PrepareContext implements the driver.ConnPrepareContext interface.
 func (c *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
 	// TODO option for create_proc
 	return c.NewStmt(ctx, "", query, true)
 }
 
 // NewStmt creates a new statement.
 func (c *Conn) NewStmt(ctx context.Context, name, query string, create_proc bool) (*Stmt, error) {
-	stmt := &Stmt{conn: c}
+	stmt := &Stmt{conn: c, query: query}
 
 	if name == "" {
 		// TODO different pools for procs and prepares
@@ -73,7 +80,10 @@ func (c *Conn) NewStmt(ctx context.Context, name, query string, create_proc bool
 	// Reset statement to default before proceeding
 	stmt.Reset()
 
-	if err := stmt.allocateOnServer(ctx); err != nil {
+	start := time.Now()
+	err := stmt.allocateOnServer(ctx)
+	c.tracer.Prepare(ctx, PrepareEvent{Query: query, Err: err, Duration: time.Since(start)})
+	if err != nil {
 		return nil, fmt.Errorf("go-ase: error allocating dynamic statement '%s': %w", query, err)
 	}
 
@@ -83,17 +93,35 @@ func (c *Conn) NewStmt(ctx context.Context, name, query string, create_proc bool
 // allocateOnServer communicates the allocation of the dynamic statement
 // on the server and retrieves the input and output formats.
 func (stmt *Stmt) allocateOnServer(ctx context.Context) error {
+	acknowledged, err := stmt.prepare(ctx)
+	if err != nil && acknowledged {
+		// The server acknowledged the statement, deallocate it.
+		stmt.close(ctx)
+	}
+
+	return err
+}
+
+// prepare sends the dynamic statement to the server and reports
+// whether the server acknowledged it.
+func (stmt *Stmt) prepare(ctx context.Context) (bool, error) {
+	if err := stmt.conn.acquire(ctx); err != nil {
+		return false, err
+	}
+	defer stmt.conn.release()
+
 	stmt.pkg.Type = tds.TDS_DYN_PREPARE
-	if err := stmt.conn.Channel.SendPackage(ctx, stmt.pkg); err != nil {
-		return fmt.Errorf("error queueing dynamic prepare package: %w", err)
+	stmt.conn.stats.roundTrip()
+	if err := stmt.conn.channel.SendPackage(ctx, stmt.pkg); err != nil {
+		return false, fmt.Errorf("error queueing dynamic prepare package: %w", err)
 	}
 	stmt.Reset()
 
 	if err := stmt.recvDynAck(ctx); err != nil {
-		return err
+		return false, err
 	}
 
-	_, err := stmt.conn.Channel.NextPackageUntil(ctx, true,
+	_, err := stmt.conn.nextPackageUntil(ctx, true,
 		func(pkg tds.Package) (bool, error) {
 			switch typed := pkg.(type) {
 			case *tds.ParamFmtPackage:
@@ -103,7 +131,7 @@ func (stmt *Stmt) allocateOnServer(ctx context.Context) error {
 				stmt.rowFmt = typed
 				return false, nil
 			case *tds.DonePackage:
-				ok, err := handleDonePackage(typed)
+				ok, err := stmt.conn.handleDonePackage(typed)
 				if err != nil {
 					return true, err
 				}
@@ -114,11 +142,10 @@ func (stmt *Stmt) allocateOnServer(ctx context.Context) error {
 		},
 	)
 	if err != nil && !errors.Is(err, io.EOF) {
-		stmt.close(ctx)
-		return err
+		return true, err
 	}
 
-	return nil
+	return true, nil
 }
 
 // Reset resets a statement.
@@ -133,6 +160,11 @@ func (stmt *Stmt) Close() error {
 }
 
 func (stmt *Stmt) close(ctx context.Context) error {
+	if err := stmt.conn.acquire(ctx); err != nil {
+		return err
+	}
+	defer stmt.conn.release()
+
 	if stmt.stmtId != nil {
 		defer stmtIdPool.Release(stmt.stmtId)
 	}
@@ -140,7 +172,8 @@ func (stmt *Stmt) close(ctx context.Context) error {
 	// communicate deallocation with server
 	// TODO option to not deallocate procs
 	stmt.pkg.Type = tds.TDS_DYN_DEALLOC
-	if err := stmt.conn.Channel.SendPackage(ctx, stmt.pkg); err != nil {
+	stmt.conn.stats.roundTrip()
+	if err := stmt.conn.channel.SendPackage(ctx, stmt.pkg); err != nil {
 		return fmt.Errorf("error sending dealloc package: %w", err)
 	}
 	stmt.Reset()
@@ -149,7 +182,7 @@ func (stmt *Stmt) close(ctx context.Context) error {
 		return err
 	}
 
-	_, err := stmt.conn.Channel.NextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+	_, err := stmt.conn.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
 		switch typed := pkg.(type) {
 		case *tds.CurInfoPackage:
 			if typed.Command != tds.TDS_CUR_CMD_INFORM {
@@ -162,12 +195,16 @@ func (stmt *Stmt) close(ctx context.Context) error {
 			}
 			return false, nil
 		case *tds.DonePackage:
-			if typed.Status != tds.TDS_DONE_FINAL {
+			stmt.conn.trackTransaction(typed)
+			// TDS_DONE_INXACT is set if the statement is deallocated
+			// in a transaction.
+			if typed.Status&^tds.TDS_DONE_INXACT != tds.TDS_DONE_FINAL {
 				return false, fmt.Errorf("DonePackage does not have status TDS_DONE_FINAL set: %s", typed)
 			}
 
 			return true, io.EOF
 		default:
+			stmt.conn.logUnhandledPackage(ctx, "statement deallocate", typed)
 			return true, fmt.Errorf("unhandled package type %T: %s", typed, typed)
 		}
 	})
@@ -179,7 +216,7 @@ func (stmt *Stmt) close(ctx context.Context) error {
 }
 
 // NumInput implements the driver.Stmt interface.
-func (stmt Stmt) NumInput() int {
+func (stmt *Stmt) NumInput() int {
 	if stmt.paramFmt == nil || stmt.paramFmt.Fmts == nil {
 		return 0
 	}
@@ -187,27 +224,43 @@ func (stmt Stmt) NumInput() int {
 }
 
 // Exec implements the driver.Stmt interface.
-func (stmt Stmt) Exec(args []driver.Value) (driver.Result, error) {
+func (stmt *Stmt) Exec(args []driver.Value) (driver.Result, error) {
 	return stmt.ExecContext(context.Background(), dblib.ValuesToNamedValues(args))
 }
 
 // ExecContext implements the driver.StmtExecContext interface.
-func (stmt Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+func (stmt *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := stmt.conn.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
+	ctx, end := stmt.conn.traceQuery(ctx, stmt.query, args)
+
 	rows, result, err := stmt.GenericExec(ctx, args)
 	if rows != nil {
 		rows.Close()
 	}
+
+	end(result, err)
 	return result, err
 }
 
 // Query implements the driver.Stmt interface.
-func (stmt Stmt) Query(args []driver.Value) (driver.Rows, error) {
+func (stmt *Stmt) Query(args []driver.Value) (driver.Rows, error) {
 	return stmt.QueryContext(context.Background(), dblib.ValuesToNamedValues(args))
 }
 
 // QueryContext implements the driver.StmtQueryContext interface.
-func (stmt Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+func (stmt *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := stmt.conn.xaCheckActive(); err != nil {
+		return nil, err
+	}
+
+	ctx, end := stmt.conn.traceQuery(ctx, stmt.query, args)
+
 	rows, _, err := stmt.GenericExec(ctx, args)
+
+	end(nil, err)
 	return rows, err
 }
 
@@ -217,7 +270,7 @@ func (stmt Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (dr
 // The primary advantage are the variadic args, which can be normal
 // values and are automatically transformed to driver.NamedValues for
 // GenericExec.
-func (stmt Stmt) DirectExec(ctx context.Context, args ...interface{}) (driver.Rows, driver.Result, error) {
+func (stmt *Stmt) DirectExec(ctx context.Context, args ...interface{}) (driver.Rows, driver.Result, error) {
 	var namedArgs []driver.NamedValue
 	if len(args) > 0 {
 		values := make([]driver.Value, len(args))
@@ -232,13 +285,18 @@ func (stmt Stmt) DirectExec(ctx context.Context, args ...interface{}) (driver.Ro
 
 // GenericExec is the central method through which SQL statements are
 // sent to ASE.
-func (stmt Stmt) GenericExec(ctx context.Context, args []driver.NamedValue) (driver.Rows, driver.Result, error) {
+func (stmt *Stmt) GenericExec(ctx context.Context, args []driver.NamedValue) (driver.Rows, driver.Result, error) {
+	if err := stmt.conn.acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer stmt.conn.release()
+
 	// Prepare and send payload
 	stmt.pkg.Type = tds.TDS_DYN_EXEC
 	if stmt.paramFmt != nil {
 		stmt.pkg.Status |= tds.TDS_DYNAMIC_HASARGS
 	}
-	if err := stmt.conn.Channel.QueuePackage(ctx, stmt.pkg); err != nil {
+	if err := stmt.conn.channel.QueuePackage(ctx, stmt.pkg); err != nil {
 		return nil, nil, fmt.Errorf("error queueing dynamic statement exec package: %w", err)
 	}
 	stmt.Reset()
@@ -249,7 +307,8 @@ func (stmt Stmt) GenericExec(ctx context.Context, args []driver.NamedValue) (dri
 		}
 	}
 
-	if err := stmt.conn.Channel.SendRemainingPackets(ctx); err != nil {
+	stmt.conn.stats.roundTrip()
+	if err := stmt.conn.channel.SendRemainingPackets(ctx); err != nil {
 		return nil, nil, fmt.Errorf("error sending queued packages for dynamic statement execution: %w", err)
 	}
 
@@ -261,7 +320,7 @@ func (stmt Stmt) GenericExec(ctx context.Context, args []driver.NamedValue) (dri
 	return stmt.conn.genericResults(ctx)
 }
 
-func (stmt Stmt) sendArgs(ctx context.Context, args []driver.NamedValue) error {
+func (stmt *Stmt) sendArgs(ctx context.Context, args []driver.NamedValue) error {
 
 	dataFields := []tds.FieldData{}
 
@@ -293,10 +352,10 @@ func (stmt Stmt) sendArgs(ctx context.Context, args []driver.NamedValue) error {
 		dataFields = append(dataFields, dataField)
 	}
 
-	if err := stmt.conn.Channel.QueuePackage(ctx, stmt.paramFmt); err != nil {
+	if err := stmt.conn.channel.QueuePackage(ctx, stmt.paramFmt); err != nil {
 		return fmt.Errorf("error queueing dynamic statement parameter format: %w", err)
 	}
-	if err := stmt.conn.Channel.QueuePackage(ctx, tds.NewParamsPackage(dataFields...)); err != nil {
+	if err := stmt.conn.channel.QueuePackage(ctx, tds.NewParamsPackage(dataFields...)); err != nil {
 		return fmt.Errorf("error queueing dynamic statement parameters: %w", err)
 	}
 
@@ -304,7 +363,7 @@ func (stmt Stmt) sendArgs(ctx context.Context, args []driver.NamedValue) error {
 }
 
 // CheckNamedValue implements the driver.NamedValueChecker interface.
-func (stmt Stmt) CheckNamedValue(nv *driver.NamedValue) error {
+func (stmt *Stmt) CheckNamedValue(nv *driver.NamedValue) error {
 	if stmt.paramFmt == nil || len(stmt.paramFmt.Fmts) == 0 {
 		return errors.New("go-ase: statement has no reported arguments")
 	}
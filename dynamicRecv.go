@@ -13,8 +13,8 @@ import (
 	"github.com/SAP/go-dblib/tds"
 )
 
-func (stmt Stmt) recvDynAck(ctx context.Context) error {
-	_, err := stmt.conn.Channel.NextPackageUntil(ctx, true,
+func (stmt *Stmt) recvDynAck(ctx context.Context) error {
+	_, err := stmt.conn.nextPackageUntil(ctx, true,
 		func(pkg tds.Package) (bool, error) {
 			ack, ok := pkg.(*tds.DynamicPackage)
 			if !ok {
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Session is the state of the session of a connection as reported by
+// the server through TDS_ENVCHANGE.
+//
+// Values the server did not report are empty.
+//
+// TDS 5.0 does not report the sort order through TDS_ENVCHANGE, it is
+// configured server-wide and can be retrieved with sp_helpsort.
+type Session struct {
+	// Database is the current database.
+	Database string
+	// Language is the language of server messages.
+	Language string
+	// Charset is the character set of the connection.
+	Charset string
+	// PacketSize is the negotiated packet size in bytes.
+	PacketSize int
+}
+
+// connEnv records the session state of a connection as reported by the
+// server through TDS_ENVCHANGE.
+//
+// The hook is called by go-dblib while parsing packages, hence the
+// session is guarded by lock.
+type connEnv struct {
+	lock    sync.Mutex
+	session Session
+}
+
+// hook implements tds.EnvChangeHook.
+func (env *connEnv) hook(typ tds.EnvChangeType, oldValue, newValue string) {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	switch typ {
+	case tds.TDS_ENV_DB:
+		env.session.Database = newValue
+	case tds.TDS_ENV_LANG:
+		env.session.Language = newValue
+	case tds.TDS_ENV_CHARSET:
+		env.session.Charset = newValue
+	case tds.TDS_ENV_PACKSIZE:
+		// go-dblib rejects invalid packet sizes before calling hooks.
+		if packetSize, err := strconv.Atoi(newValue); err == nil {
+			env.session.PacketSize = packetSize
+		}
+	}
+}
+
+// Session returns the recorded session state.
+func (env *connEnv) Session() Session {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	return env.session
+}
+
+// Session returns the state of the session as last reported by the
+// server, e.g. the current database after a `use` statement.
+func (c *Conn) Session() Session {
+	return c.env.Session()
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Message numbers sent by ASE in EED packages that are handled by the
+// driver.
+const (
+	// msgLoginFailed is sent when the login is rejected, e.g. due to
+	// invalid credentials.
+	msgLoginFailed uint32 = 4002
+	// msgPasswordExpired is sent when the password of the login has
+	// expired. The server only permits the execution of sp_password.
+	msgPasswordExpired uint32 = 4022
+	// msgPasswordExpiresSoon is sent when the password of the login
+	// is about to expire.
+	msgPasswordExpiresSoon uint32 = 4023
+	// msgDeadlock is sent to the victim of a deadlock, whose
+	// transaction has been rolled back.
+	msgDeadlock uint32 = 1205
+	// msgLockTimeout and msgLockTimeoutSession are sent when a lock
+	// could not be acquired within the lock wait period, which aborts
+	// the transaction.
+	msgLockTimeout        uint32 = 12205
+	msgLockTimeoutSession uint32 = 12207
+)
+
+// ErrPasswordExpired is matched by errors.Is if the login failed
+// because the password of the login has expired.
+var ErrPasswordExpired = errors.New("go-ase: password expired")
+
+// PasswordExpiredError is returned when ASE reports that the password
+// of the login has expired.
+//
+// Use ChangePassword to set a new password.
+type PasswordExpiredError struct {
+	// EED is the message sent by ASE.
+	EED tds.EEDPackage
+	// Err is the error returned by the login negotiation, if any.
+	Err error
+}
+
+func (err *PasswordExpiredError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("go-ase: password expired: %d: %s: %v", err.EED.MsgNumber, err.EED.Msg, err.Err)
+	}
+	return fmt.Sprintf("go-ase: password expired: %d: %s", err.EED.MsgNumber, err.EED.Msg)
+}
+
+// Is reports whether target is ErrPasswordExpired.
+func (err *PasswordExpiredError) Is(target error) bool {
+	return target == ErrPasswordExpired
+}
+
+// Unwrap returns the error returned by the login negotiation.
+func (err *PasswordExpiredError) Unwrap() error {
+	return err.Err
+}
+
+// hasMsgNumber reports whether err wraps a tds.EEDError containing an
+// EEDPackage with the passed message number.
+func hasMsgNumber(err error, msgNumber uint32) bool {
+	var eedError *tds.EEDError
+	if !errors.As(err, &eedError) {
+		return false
+	}
+
+	for _, eed := range eedError.EEDPackages {
+		if eed.MsgNumber == msgNumber {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MessageNumber returns the number of the first error message sent by
+// the server that caused err.
+//
+// Error messages are messages with a severity above 10. If err does not
+// contain an error message the number of the first message is returned
+// instead. The second return value is false if err does not contain any
+// message from the server.
+func MessageNumber(err error) (uint32, bool) {
+	var eedError *tds.EEDError
+	if !errors.As(err, &eedError) || len(eedError.EEDPackages) == 0 {
+		return 0, false
+	}
+
+	for _, eed := range eedError.EEDPackages {
+		if eed.Class > 10 {
+			return eed.MsgNumber, true
+		}
+	}
+
+	return eedError.EEDPackages[0].MsgNumber, true
+}
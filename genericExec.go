@@ -44,7 +44,7 @@ func (c *Conn) GenericExec(ctx context.Context, query string, args []driver.Name
 		return rows, result, nil
 	}
 
-	stmt, err := c.NewStmt(ctx, "", query, true)
+	stmt, err := c.cachedStmt(ctx, query)
 	if err != nil {
 		return nil, nil, fmt.Errorf("go-ase: error creating prepared statement: %w", err)
 	}
@@ -61,33 +61,15 @@ func (c *Conn) genericResults(ctx context.Context) (driver.Rows, driver.Result,
 	rows := c.NewRows()
 	result := &Result{}
 
-	_, err := c.Channel.NextPackageUntil(ctx, true,
-		func(pkg tds.Package) (bool, error) {
-			switch typed := pkg.(type) {
-			case *tds.RowFmtPackage:
-				rows.RowFmt = typed
-				return true, nil
-			case *tds.DonePackage:
-				if typed.Status&tds.TDS_DONE_COUNT == tds.TDS_DONE_COUNT {
-					result.rowsAffected = int64(typed.Count)
-				}
-
-				ok, err := handleDonePackage(typed)
-				if err != nil {
-					return true, fmt.Errorf("go-ase: %w", err)
-				}
-
-				return ok, nil
-			case *tds.ReturnStatusPackage:
-				if typed.ReturnValue != 0 {
-					return true, fmt.Errorf("go-ase: query failed with return status %d", typed.ReturnValue)
-				}
-				return false, nil
-			default:
-				return false, fmt.Errorf("go-ase: unhandled package type %T", typed)
-			}
+	_, err := c.readStream(ctx, true, resultStream{
+		operation: "statement",
+		done:      doneResults,
+		count:     func(count int32) { result.rowsAffected = int64(count) },
+		rowFmt: func(pkg *tds.RowFmtPackage) (bool, error) {
+			rows.RowFmt = pkg
+			return true, nil
 		},
-	)
+	})
 	if err != nil && !errors.Is(err, io.EOF) {
 		return nil, nil, err
 	}
@@ -97,6 +79,9 @@ func (c *Conn) genericResults(ctx context.Context) (driver.Rows, driver.Result,
 	// application.
 	if errors.Is(err, io.EOF) {
 		rows.closed = true
+	} else {
+		// The rows occupy the connection until they are closed.
+		c.openRows = rows
 	}
 
 	return rows, result, nil
@@ -20,3 +20,7 @@ require (
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
 	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f // indirect
 )
+
+// third_party/go-dblib is go-dblib with the changes go-ase requires that
+// are not released yet, e.g. tds.NewConnWithConn.
+replace github.com/SAP/go-dblib => ./third_party/go-dblib
@@ -5,6 +5,8 @@
 package ase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -32,3 +34,124 @@ func handleDonePackage(pkg *tds.DonePackage) (bool, error) {
 
 	return false, fmt.Errorf("%T with unrecognized Status: %s", pkg, pkg)
 }
+
+// handleDonePackage records the transaction state reported by pkg on
+// the connection before handling it with handleDonePackage.
+func (c *Conn) handleDonePackage(pkg *tds.DonePackage) (bool, error) {
+	c.trackTransaction(pkg)
+
+	ok, err := handleDonePackage(pkg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		// The remaining packages of the response are consumed by
+		// nextPackageUntil.
+		c.pendingDone = false
+	}
+
+	return ok, err
+}
+
+// nextPackageUntil calls processPkg with the packages received on the
+// channel of the connection until it returns true, with the semantics
+// of tds.Channel.NextPackageUntil.
+//
+// Unlike tds.Channel.NextPackageUntil it passes the EEDPackages to the
+// MessageHandler of the current call in the order they are received.
+func (c *Conn) nextPackageUntil(ctx context.Context, wait bool, processPkg func(tds.Package) (bool, error)) (tds.Package, error) {
+	eedError := &tds.EEDError{}
+
+	for {
+		pkg, err := c.nextPackage(ctx, wait)
+		if err != nil {
+			return nil, err
+		}
+
+		// More packages are received until processPkg signals that the
+		// communication has finished.
+		wait = true
+
+		c.handleMessagePackage(pkg)
+
+		if eed, ok := pkg.(*tds.EEDPackage); ok {
+			eedError.Add(eed)
+			continue
+		}
+
+		if processPkg == nil {
+			if isDoneFinal(pkg) {
+				return nil, io.EOF
+			}
+
+			_, err := c.nextPackageUntil(ctx, wait, func(pkg tds.Package) (bool, error) {
+				return isDoneFinal(pkg), nil
+			})
+			return nil, err
+		}
+
+		ok, err := processPkg(pkg)
+		if err != nil {
+			// An unwrapped io.EOF signals a new result set to
+			// rows-like consumers.
+			if err == io.EOF {
+				return pkg, io.EOF
+			}
+
+			// Consume the remaining packages of the response to not
+			// impact later communication.
+			if !isDoneFinal(pkg) {
+				_, err := c.nextPackageUntil(ctx, wait, nil)
+				var finalEEDError *tds.EEDError
+				if err != nil && errors.As(err, &finalEEDError) {
+					eedError.EEDPackages = append(eedError.EEDPackages, finalEEDError.EEDPackages...)
+				}
+			}
+
+			err = fmt.Errorf("tds: error in user-defined processing function: %w", err)
+
+			if len(eedError.EEDPackages) == 0 {
+				return nil, err
+			}
+
+			eedError.WrappedError = err
+			return nil, eedError
+		}
+
+		if ok {
+			return pkg, nil
+		}
+	}
+}
+
+// nextPackage returns the next package of the buffered response of the
+// current exchange or received on the channel of the connection.
+func (c *Conn) nextPackage(ctx context.Context, wait bool) (tds.Package, error) {
+	if c.buffer == nil {
+		pkg, err := c.channel.NextPackage(ctx, wait)
+		if err == nil && isDoneFinal(pkg) && c.openRows != nil {
+			// The response has been read completely, the rows no
+			// longer occupy the channel.
+			c.openRows.closed = true
+			c.openRows = nil
+		}
+		return pkg, err
+	}
+
+	if len(*c.buffer) == 0 {
+		// The rest of a partially buffered response is read from
+		// the channel.
+		if c.openRows != nil && c.buffer == &c.openRows.buffer {
+			c.buffer = nil
+			return c.nextPackage(ctx, wait)
+		}
+		return nil, tds.ErrNoPackageReady
+	}
+
+	pkg := (*c.buffer)[0]
+	*c.buffer = (*c.buffer)[1:]
+	return pkg, nil
+}
+
+// isDoneFinal reports whether pkg is a DonePackage ending a response.
+func isDoneFinal(pkg tds.Package) bool {
+	done, ok := pkg.(*tds.DonePackage)
+	return ok && done.Status == tds.TDS_DONE_FINAL
+}
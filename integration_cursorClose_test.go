@@ -8,7 +8,6 @@
 package ase
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
 	"testing"
@@ -69,159 +68,3 @@ func createTable(db *sql.DB, tableName string) error {
 
 	return nil
 }
-
-func cursorFetch(t *testing.T, cursor *Cursor) {
-	rows, err := cursor.Fetch(context.Background())
-	if err != nil {
-		t.Errorf("error fetching result set: %v", err)
-		return
-	}
-
-	fetchRows(t, rows)
-
-	if err := rows.Close(); err != nil {
-		t.Errorf("error closing rows: %v", err)
-	}
-}
-
-func singleCursor(t *testing.T, conn *Conn, tableName string) {
-	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
-	if err != nil {
-		t.Errorf("error creating cursor: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor)
-}
-
-func singleCursorWithArgs(t *testing.T, conn *Conn, tableName string) {
-	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
-	if err != nil {
-		t.Errorf("error creating cursor: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor)
-}
-
-func twoCursors(t *testing.T, conn *Conn, tableName string) {
-	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
-	if err != nil {
-		t.Errorf("error creating cursor: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor)
-
-	cursor2, err := conn.NewCursor(context.Background(), "select * from "+tableName)
-	if err != nil {
-		t.Errorf("error creating cursor2: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor2.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor2: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor2)
-}
-
-func twoCursorsOneWithArgs(t *testing.T, conn *Conn, tableName string) {
-	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
-	if err != nil {
-		t.Errorf("error creating cursor: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor)
-
-	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
-	if err != nil {
-		t.Errorf("error creating cursorWithArgs: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursorWithArgs.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursorWithArgs: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursorWithArgs)
-}
-
-func twoCursorsOneWithArgs2(t *testing.T, conn *Conn, tableName string) {
-	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
-	if err != nil {
-		t.Errorf("error creating cursorWithArgs: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursorWithArgs.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursorWithArgs: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursorWithArgs)
-
-	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
-	if err != nil {
-		t.Errorf("error creating cursor: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursor: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursor)
-}
-
-func twoCursorsWithArgs(t *testing.T, conn *Conn, tableName string) {
-	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
-	if err != nil {
-		t.Errorf("error creating cursorWithArgs: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursorWithArgs.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursorWithArgs: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursorWithArgs)
-
-	cursorWithArgs2, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
-	if err != nil {
-		t.Errorf("error creating cursorWithArgs2: %v", err)
-		return
-	}
-	defer func() {
-		if err := cursorWithArgs2.Close(context.Background()); err != nil {
-			t.Errorf("error closing cursorWithArgs2: %v", err)
-		}
-	}()
-
-	cursorFetch(t, cursorWithArgs2)
-}
@@ -7,11 +7,8 @@
 package ase
 
 import (
-	"context"
 	"database/sql"
-	"fmt"
 	"testing"
-	"time"
 
 	"github.com/SAP/go-dblib/integration"
 )
@@ -45,29 +42,5 @@ func TestDirectExec(t *testing.T) {
 }
 
 func directExecWrapper(t *testing.T, db *sql.DB, tableName, query string, args ...interface{}) {
-	timeout, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	wrapper(t, db, tableName,
-		func(t *testing.T, conn *Conn, tableName string) {
-			rows, result, err := conn.DirectExec(timeout, fmt.Sprintf(query, tableName), args...)
-			if err != nil {
-				t.Errorf("received error on DirectExec: %v", err)
-				return
-			}
-
-			if rows == nil {
-				t.Errorf("received nil rows")
-			} else {
-				fetchRows(t, rows)
-				if err := rows.Close(); err != nil {
-					t.Errorf("error closing rows: %v", err)
-				}
-			}
-
-			if result == nil {
-				t.Errorf("received nil result")
-			}
-		},
-	)
+	wrapper(t, db, tableName, directExec(query, args...))
 }
@@ -9,10 +9,6 @@ package ase
 import (
 	"context"
 	"database/sql"
-	"database/sql/driver"
-	"errors"
-	"fmt"
-	"io"
 	"testing"
 )
 
@@ -46,27 +42,3 @@ func wrapper(t *testing.T, db *sql.DB, tableName string, runner func(*testing.T,
 		return nil
 	})
 }
-
-// interface to match both Rows and CursorRows.
-type sqlRows interface {
-	Next([]driver.Value) error
-}
-
-// fetchRows expects the passed rows to return {int, string} and prints
-// all rows to stdout.
-//
-// rows is not closed automatically.
-func fetchRows(t *testing.T, rows sqlRows) {
-	values := []driver.Value{0, ""}
-	for {
-		if err := rows.Next(values); err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			t.Errorf("error reading row: %v", err)
-			return
-		}
-
-		fmt.Printf("| %d | %s |\n", values[0], values[1])
-	}
-}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/SAP/go-dblib/integration"
+)
+
+func TestXA(t *testing.T) {
+	t.Run("TwoPhaseCommit", func(t *testing.T) {
+		integration.TestForEachDB("TestXATwoPhaseCommit", t, func(t *testing.T, db *sql.DB, tableName string) {
+			xaWrapper(t, db, tableName, xaScenarioTwoPhaseCommit)
+		})
+	})
+
+	t.Run("OnePhaseCommit", func(t *testing.T) {
+		integration.TestForEachDB("TestXAOnePhaseCommit", t, func(t *testing.T, db *sql.DB, tableName string) {
+			xaWrapper(t, db, tableName, xaScenarioOnePhaseCommit)
+		})
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		integration.TestForEachDB("TestXARollback", t, func(t *testing.T, db *sql.DB, tableName string) {
+			xaWrapper(t, db, tableName, xaScenarioRollback)
+		})
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		integration.TestForEachDB("TestXARecovery", t, func(t *testing.T, db *sql.DB, tableName string) {
+			xaWrapper(t, db, tableName, xaScenarioRecovery)
+		})
+	})
+}
+
+// xaWrapper creates the table for an XA scenario and skips the
+// scenario if distributed transaction management is not enabled.
+func xaWrapper(t *testing.T, db *sql.DB, tableName string, testFn func(*testing.T, *sql.DB, string)) {
+	var enabled int
+	if err := db.QueryRow("select c.value from master..syscurconfigs c, master..sysconfigures f" +
+		" where c.config = f.config and f.name = 'enable DTM'").Scan(&enabled); err != nil {
+		t.Fatalf("error reading configuration 'enable DTM': %v", err)
+	}
+	if enabled == 0 {
+		t.Skip("distributed transaction management is not enabled")
+	}
+
+	if _, err := db.Exec("create table " + tableName + " (a int)"); err != nil {
+		t.Fatalf("error creating table %s: %v", tableName, err)
+	}
+	defer db.Exec("drop table " + tableName)
+
+	testFn(t, db, tableName)
+}
+
+// newXAIntegrationXID returns an XID that is unique across test runs.
+func newXAIntegrationXID() XID {
+	return XID{
+		FormatID:            1,
+		GlobalTransactionID: []byte("go-ase-" + integration.RandomNumber()),
+		BranchQualifier:     []byte{1},
+	}
+}
+
+// withRawConn calls fn with a connection of db.
+func withRawConn(t *testing.T, db *sql.DB, fn func(conn *Conn) error) {
+	t.Helper()
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("error retrieving connection: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn interface{}) error {
+		return fn(driverConn.(*Conn))
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// xaCount returns the number of rows in the table of the scenario.
+func xaCount(t *testing.T, db *sql.DB, tableName string) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow("select count(*) from " + tableName).Scan(&count); err != nil {
+		t.Fatalf("error counting rows: %v", err)
+	}
+	return count
+}
+
+func xaScenarioTwoPhaseCommit(t *testing.T, db *sql.DB, tableName string) {
+	ctx := context.Background()
+	xid := newXAIntegrationXID()
+
+	withRawConn(t, db, func(conn *Conn) error {
+		if _, err := conn.XAStart(ctx, xid); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "insert into "+tableName+" values (1)", nil); err != nil {
+			return err
+		}
+
+		if err := conn.XAPrepare(ctx, xid); !errors.Is(err, ErrXAProtocol) {
+			t.Errorf("expected ErrXAProtocol preparing active branch, got: %v", err)
+		}
+
+		if err := conn.XAEnd(ctx, xid); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "insert into "+tableName+" values (2)", nil); !errors.Is(err, ErrXAProtocol) {
+			t.Errorf("expected ErrXAProtocol executing statement on ended branch, got: %v", err)
+		}
+
+		if err := conn.XAPrepare(ctx, xid); err != nil {
+			return err
+		}
+
+		return conn.XACommit(ctx, xid, false)
+	})
+
+	if count := xaCount(t, db, tableName); count != 1 {
+		t.Errorf("expected 1 committed row, got %d", count)
+	}
+}
+
+func xaScenarioOnePhaseCommit(t *testing.T, db *sql.DB, tableName string) {
+	ctx := context.Background()
+	xid := newXAIntegrationXID()
+
+	withRawConn(t, db, func(conn *Conn) error {
+		if _, err := conn.XAStart(ctx, xid); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "insert into "+tableName+" values (1)", nil); err != nil {
+			return err
+		}
+
+		if err := conn.XAEnd(ctx, xid); err != nil {
+			return err
+		}
+
+		return conn.XACommit(ctx, xid, true)
+	})
+
+	if count := xaCount(t, db, tableName); count != 1 {
+		t.Errorf("expected 1 committed row, got %d", count)
+	}
+}
+
+func xaScenarioRollback(t *testing.T, db *sql.DB, tableName string) {
+	ctx := context.Background()
+	xid := newXAIntegrationXID()
+
+	withRawConn(t, db, func(conn *Conn) error {
+		if _, err := conn.XAStart(ctx, xid); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, "insert into "+tableName+" values (1)", nil); err != nil {
+			return err
+		}
+
+		if err := conn.XAEnd(ctx, xid); err != nil {
+			return err
+		}
+
+		if err := conn.XAPrepare(ctx, xid); err != nil {
+			return err
+		}
+
+		return conn.XARollback(ctx, xid)
+	})
+
+	if count := xaCount(t, db, tableName); count != 0 {
+		t.Errorf("expected no committed rows, got %d", count)
+	}
+}
+
+func xaScenarioRecovery(t *testing.T, db *sql.DB, tableName string) {
+	ctx := context.Background()
+	xid := newXAIntegrationXID()
+
+	// The prepared branch survives the loss of the connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("error retrieving connection: %v", err)
+	}
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		c := driverConn.(*Conn)
+
+		if _, err := c.XAStart(ctx, xid); err != nil {
+			return err
+		}
+
+		if _, err := c.ExecContext(ctx, "insert into "+tableName+" values (1)", nil); err != nil {
+			return err
+		}
+
+		if err := c.XAEnd(ctx, xid); err != nil {
+			return err
+		}
+
+		if err := c.XAPrepare(ctx, xid); err != nil {
+			return err
+		}
+
+		// database/sql closes connections reported as bad, which
+		// drops the session without completing the branch.
+		return driver.ErrBadConn
+	})
+	if !errors.Is(err, driver.ErrBadConn) {
+		t.Fatalf("error preparing transaction branch: %v", err)
+	}
+	conn.Close()
+
+	withRawConn(t, db, func(conn *Conn) error {
+		xids, err := conn.XARecover(ctx)
+		if err != nil {
+			return err
+		}
+
+		recovered := false
+		for _, recoveredXID := range xids {
+			if recoveredXID.String() == xid.String() {
+				recovered = true
+			}
+		}
+		if !recovered {
+			t.Errorf("expected %s in recovered branches %v", xid, xids)
+		}
+
+		return conn.XACommit(ctx, xid, false)
+	})
+
+	if count := xaCount(t, db, tableName); count != 1 {
+		t.Errorf("expected 1 committed row, got %d", count)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// aseIsolationLevels maps sql.IsolationLevel to the isolation levels of
+// ASE.
+var aseIsolationLevels = map[sql.IsolationLevel]byte{
+	sql.LevelReadUncommitted: 0,
+	sql.LevelReadCommitted:   1,
+	sql.LevelRepeatableRead:  2,
+	sql.LevelSerializable:    3,
+}
+
+// aseIsolationLevel returns the ASE isolation level for level.
+func aseIsolationLevel(level sql.IsolationLevel) (byte, error) {
+	aseLevel, ok := aseIsolationLevels[level]
+	if !ok {
+		return 0, fmt.Errorf("go-ase: sql.IsolationLevel %s has no equivalent ASE isolation level", level)
+	}
+
+	return aseLevel, nil
+}
+
+// isolationLevelFromASE returns the sql.IsolationLevel for an ASE
+// isolation level.
+func isolationLevelFromASE(aseLevel byte) (sql.IsolationLevel, error) {
+	for level, candidate := range aseIsolationLevels {
+		if candidate == aseLevel {
+			return level, nil
+		}
+	}
+
+	return sql.LevelDefault, fmt.Errorf("go-ase: invalid ASE isolation level %d", aseLevel)
+}
+
+type statementIsolationKey struct{}
+
+// WithStatementIsolation returns a context that causes queries and
+// cursors opened with it to run at the passed isolation level by
+// appending an `at isolation` clause to the query.
+//
+// The clause is only applied to the query passed to Conn.QueryContext
+// or Conn.NewCursor - statements executed with ExecContext and the
+// statements the driver executes itself, e.g. when connecting, are
+// not changed. The isolation level of the connection and of open
+// transactions is not changed either.
+//
+// ASE only accepts the clause for a single select statement, hence the
+// query must not be a batch of multiple statements.
+func WithStatementIsolation(ctx context.Context, level sql.IsolationLevel) context.Context {
+	return context.WithValue(ctx, statementIsolationKey{}, level)
+}
+
+// withStatementIsolation returns query with the `at isolation` clause
+// if an isolation level was set on ctx with WithStatementIsolation.
+//
+// The returned context no longer carries the isolation level so the
+// statements executed to run the query are not changed.
+func withStatementIsolation(ctx context.Context, query string) (context.Context, string, error) {
+	level, ok := ctx.Value(statementIsolationKey{}).(sql.IsolationLevel)
+	if !ok {
+		return ctx, query, nil
+	}
+
+	aseLevel, err := aseIsolationLevel(level)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The clause must follow the statement and not a terminating
+	// semicolon. It is placed on a new line so a trailing line comment
+	// does not comment it out.
+	query = strings.TrimRight(query, "; \t\r\n")
+	query += "\nat isolation " + strconv.Itoa(int(aseLevel))
+
+	return context.WithValue(ctx, statementIsolationKey{}, nil), query, nil
+}
@@ -12,13 +12,19 @@ import (
 	"github.com/SAP/go-dblib/tds"
 )
 
-func (c Conn) language(ctx context.Context, query string) (driver.Rows, driver.Result, error) {
+func (c *Conn) language(ctx context.Context, query string) (driver.Rows, driver.Result, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer c.release()
+
 	langPkg := &tds.LanguagePackage{
 		Status: tds.TDS_LANGUAGE_NOARGS,
 		Cmd:    query,
 	}
 
-	if err := c.Channel.SendPackage(ctx, langPkg); err != nil {
+	c.stats.roundTrip()
+	if err := c.channel.SendPackage(ctx, langPkg); err != nil {
 		return nil, nil, fmt.Errorf("error sending language command: %w", err)
 	}
 
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrResultSetOpen is returned when a command is sent on a connection
+// while the result set of a previous command has not been closed.
+//
+// A connection communicates with the server through a single channel.
+// The rows returned by queries that are not read through a cursor
+// occupy the channel until they are closed, unless the connection
+// buffers them, see WithMultipleResultSets.
+var ErrResultSetOpen = errors.New("go-ase: result set of a previous command is still open, close the rows first")
+
+// Exchanges with the server are serialized per connection: Conn, Stmt
+// and Cursor may be used from multiple goroutines, their calls are
+// executed one at a time. Rows and CursorRows must be used by a single
+// goroutine, as with database/sql.
+//
+// An exchange consists of sending a request and reading the response.
+// Each exchange acquires the lock of the connection, exchanges are not
+// nested. Rows returned by an exchange keep the connection occupied
+// until they are closed or buffered, any other exchange fails with
+// ErrResultSetOpen in the meantime.
+
+// acquire locks the connection for an exchange with the server. The
+// messages of the exchange are passed to the MessageHandler of ctx.
+func (c *Conn) acquire(ctx context.Context) error {
+	return c.acquireHandler(ctx, messageHandler(ctx))
+}
+
+// acquireHandler locks the connection for an exchange with the server
+// whose messages are passed to handler.
+func (c *Conn) acquireHandler(ctx context.Context, handler MessageHandler) error {
+	c.lock.Lock()
+
+	if c.openRows != nil {
+		if !c.multipleResultSets {
+			c.lock.Unlock()
+			return ErrResultSetOpen
+		}
+
+		if err := c.openRows.bufferResponse(ctx); err != nil {
+			c.lock.Unlock()
+			return err
+		}
+	}
+
+	c.messages = &messages{handler: handler}
+	return nil
+}
+
+// acquireRows locks the connection to continue reading the response of
+// the exchange that returned rows.
+func (c *Conn) acquireRows(rows *Rows) error {
+	c.lock.Lock()
+
+	// The rows of a partially buffered response read the buffer
+	// before the channel, see bufferResponse.
+	if rows.buffered || len(rows.buffer) > 0 {
+		c.buffer = &rows.buffer
+	}
+
+	if !rows.buffered && c.openRows != nil && c.openRows != rows {
+		c.lock.Unlock()
+		return ErrResultSetOpen
+	}
+
+	if rows.messages != nil {
+		c.messages = rows.messages
+	}
+	return nil
+}
+
+// release unlocks the connection after an exchange.
+func (c *Conn) release() {
+	c.buffer = nil
+	c.lock.Unlock()
+}
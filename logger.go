@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// LogLevel is the severity of a log entry.
+type LogLevel int
+
+// Levels of log entries in ascending severity.
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (level LogLevel) String() string {
+	switch level {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	}
+	return "LogLevel(" + strconv.Itoa(int(level)) + ")"
+}
+
+// Logger receives the log entries of the driver.
+//
+// keysAndValues are alternating keys and values, the keys are strings.
+// The signature matches e.g. the methods of log/slog and logr with
+// a thin adapter.
+//
+// Log is called on the goroutine executing the call and must not block.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+// LoggerFunc is an adapter to use functions as Logger.
+type LoggerFunc func(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+
+// Log implements the Logger interface.
+func (fn LoggerFunc) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	fn(ctx, level, msg, keysAndValues...)
+}
+
+// redacted replaces the values of parameters that are not permitted to
+// be logged.
+const redacted = "<redacted>"
+
+// WithLogger sets the Logger of the connector and the minimum level of
+// the entries passed to it.
+func WithLogger(logger Logger, level LogLevel) ConnectorOption {
+	return func(c *Connector) error {
+		c.Logger = logger
+		c.LogLevel = level
+		return nil
+	}
+}
+
+// WithLoggedParameters permits the values of the passed parameters to
+// be logged, the values of all other parameters are redacted.
+//
+// Named parameters are identified by their name, positional parameters
+// by their ordinal starting at 1, e.g. "1".
+func WithLoggedParameters(names ...string) ConnectorOption {
+	return func(c *Connector) error {
+		c.LoggedParameters = append(c.LoggedParameters, names...)
+		return nil
+	}
+}
+
+// connLogger passes the log entries of a connection to the Logger of
+// the connector.
+type connLogger struct {
+	logger Logger
+	level  LogLevel
+	// params are the names of parameters whose values may be logged.
+	params map[string]bool
+}
+
+func (c *Connector) newConnLogger() connLogger {
+	logger := connLogger{logger: c.Logger, level: c.LogLevel}
+
+	if len(c.LoggedParameters) > 0 {
+		logger.params = make(map[string]bool, len(c.LoggedParameters))
+		for _, name := range c.LoggedParameters {
+			logger.params[name] = true
+		}
+	}
+
+	return logger
+}
+
+// enabled reports whether entries of the passed level are logged.
+func (logger connLogger) enabled(level LogLevel) bool {
+	return logger.logger != nil && level >= logger.level
+}
+
+func (logger connLogger) log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	if !logger.enabled(level) {
+		return
+	}
+
+	logger.logger.Log(ctx, level, msg, keysAndValues...)
+}
+
+// args returns the values of the arguments for a log entry, values of
+// parameters that are not permitted to be logged are redacted.
+func (logger connLogger) args(args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, len(args))
+
+	for i, arg := range args {
+		name := arg.Name
+		if name == "" {
+			name = strconv.Itoa(arg.Ordinal)
+		}
+
+		if logger.params[name] {
+			values[i] = arg.Value
+		} else {
+			values[i] = redacted
+		}
+	}
+
+	return values
+}
+
+// logUnhandledPackage logs a package the driver did not expect in the
+// response to the passed operation.
+func (c *Conn) logUnhandledPackage(ctx context.Context, operation string, pkg tds.Package) {
+	c.logger.log(ctx, LogLevelWarn, "unhandled package type",
+		"operation", operation, "type", fmt.Sprintf("%T", pkg))
+}
+
+// logResult logs the result of an operation with the passed level if
+// it succeeded and as error if it failed with err.
+func (logger connLogger) logResult(ctx context.Context, level LogLevel, msg string, err error, keysAndValues ...interface{}) {
+	if err != nil {
+		level = LogLevelError
+		keysAndValues = append(keysAndValues, "error", err)
+	}
+
+	logger.log(ctx, level, msg, keysAndValues...)
+}
+
+// logTracer logs the events reported through the Tracer interface and
+// passes them to the next Tracer.
+//
+// Statements are logged by Conn.traceQuery, which has access to the
+// argument values.
+type logTracer struct {
+	logger connLogger
+	next   Tracer
+}
+
+// QueryStart implements the Tracer interface.
+func (tracer logTracer) QueryStart(ctx context.Context, event QueryStartEvent) context.Context {
+	return tracer.next.QueryStart(ctx, event)
+}
+
+// QueryEnd implements the Tracer interface.
+func (tracer logTracer) QueryEnd(ctx context.Context, event QueryEndEvent) {
+	tracer.next.QueryEnd(ctx, event)
+}
+
+// Prepare implements the Tracer interface.
+func (tracer logTracer) Prepare(ctx context.Context, event PrepareEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "prepare", event.Err,
+		"query", event.Query, "duration", event.Duration)
+	tracer.next.Prepare(ctx, event)
+}
+
+// CursorOpen implements the Tracer interface.
+func (tracer logTracer) CursorOpen(ctx context.Context, event CursorEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "cursor open", event.Err,
+		"cursor", event.Name, "query", event.Query, "args", event.Args,
+		"duration", event.Duration)
+	tracer.next.CursorOpen(ctx, event)
+}
+
+// CursorFetch implements the Tracer interface.
+func (tracer logTracer) CursorFetch(ctx context.Context, event CursorFetchEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "cursor fetch", event.Err,
+		"cursor", event.Name, "rows", event.Rows, "duration", event.Duration)
+	tracer.next.CursorFetch(ctx, event)
+}
+
+// CursorClose implements the Tracer interface.
+func (tracer logTracer) CursorClose(ctx context.Context, event CursorEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "cursor close", event.Err,
+		"cursor", event.Name, "duration", event.Duration)
+	tracer.next.CursorClose(ctx, event)
+}
+
+// TxBegin implements the Tracer interface.
+func (tracer logTracer) TxBegin(ctx context.Context, event TxEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "transaction begin", event.Err,
+		"name", event.Name, "isolation", event.Isolation, "duration", event.Duration)
+	tracer.next.TxBegin(ctx, event)
+}
+
+// TxCommit implements the Tracer interface.
+func (tracer logTracer) TxCommit(ctx context.Context, event TxEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "transaction commit", event.Err,
+		"name", event.Name, "duration", event.Duration)
+	tracer.next.TxCommit(ctx, event)
+}
+
+// TxRollback implements the Tracer interface.
+func (tracer logTracer) TxRollback(ctx context.Context, event TxEvent) {
+	tracer.logger.logResult(ctx, LogLevelDebug, "transaction rollback", event.Err,
+		"name", event.Name, "duration", event.Duration)
+	tracer.next.TxRollback(ctx, event)
+}
+
+// Connect implements the Tracer interface.
+func (tracer logTracer) Connect(ctx context.Context, event ConnectEvent) {
+	tracer.logger.logResult(ctx, LogLevelInfo, "connect", event.Err,
+		"network", event.Network, "address", event.Address, "duration", event.Duration)
+	tracer.next.Connect(ctx, event)
+}
+
+// Login implements the Tracer interface.
+func (tracer logTracer) Login(ctx context.Context, event LoginEvent) {
+	tracer.logger.logResult(ctx, LogLevelInfo, "login", event.Err,
+		"username", event.Username, "duration", event.Duration)
+	tracer.next.Login(ctx, event)
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// recordingLogger records log entries without durations.
+type recordingLogger struct {
+	lock    sync.Mutex
+	entries []string
+}
+
+func (logger *recordingLogger) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	fields := []string{level.String(), msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if _, ok := keysAndValues[i+1].(time.Duration); ok {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%v=%v", keysAndValues[i], keysAndValues[i+1]))
+	}
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.entries = append(logger.entries, strings.Join(fields, " "))
+}
+
+func TestLogger(t *testing.T) {
+	server := newTransactionServer(t)
+	logger := &recordingLogger{}
+	conn := server.connect(WithLogger(logger, LogLevelDebug), func(c *Connector) error {
+		c.Info.Username = "user"
+		return nil
+	})
+
+	tx, err := conn.BeginTx(context.Background(), DefaultTxOptions())
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("error rolling back transaction: %v", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatalf("error closing connection: %v", err)
+	}
+
+	expected := []string{
+		"info connect network=tcp address=" + server.Addr(),
+		"info login username=user",
+		"debug transaction begin name= isolation=Default",
+		"debug statement query=insert into t values (1) args=[] rows_affected=1",
+		"debug transaction rollback name=",
+		"info close",
+	}
+	if !reflect.DeepEqual(logger.entries, expected) {
+		t.Errorf("unexpected entries:\nexpected: %q\nreceived: %q", expected, logger.entries)
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	server := newTransactionServer(t)
+	logger := &recordingLogger{}
+	conn := server.connect(WithLogger(logger, LogLevelWarn))
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if len(logger.entries) != 0 {
+		t.Errorf("expected no entries below warn, received: %q", logger.entries)
+	}
+}
+
+func TestLoggerUnhandledPackage(t *testing.T) {
+	row := streamRow(t, 1)
+	ch := newScriptedChannel([]tds.Package{
+		&tds.ParamFmtPackage{Fmts: streamRowFmt(t).Fmts},
+		&row.ParamsPackage,
+	})
+	logger := &recordingLogger{}
+	conn := newStreamConn(ch)
+	conn.logger = (&Connector{Logger: logger, LogLevel: LogLevelWarn}).newConnLogger()
+
+	if _, err := conn.ExecContext(context.Background(), "select 1", nil); err == nil {
+		t.Fatalf("expected error for unhandled package")
+	}
+
+	if len(logger.entries) == 0 || logger.entries[0] != "warn unhandled package type operation=statement type=*tds.ParamFmtPackage" {
+		t.Errorf("expected entry for unhandled package, received: %q", logger.entries)
+	}
+}
+
+func TestLoggerArgs(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: "secret"},
+		{Ordinal: 2, Value: int64(2)},
+		{Name: "id", Ordinal: 3, Value: int64(3)},
+		{Name: "password", Ordinal: 4, Value: "secret"},
+	}
+
+	connector := &Connector{}
+	if err := WithLoggedParameters("2", "id")(connector); err != nil {
+		t.Fatalf("error applying option: %v", err)
+	}
+
+	expected := []interface{}{redacted, int64(2), int64(3), redacted}
+	if values := connector.newConnLogger().args(args); !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values:\nexpected: %v\nreceived: %v", expected, values)
+	}
+
+	// Without allowlist all values are redacted.
+	expected = []interface{}{redacted, redacted, redacted, redacted}
+	if values := (&Connector{}).newConnLogger().args(args); !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values:\nexpected: %v\nreceived: %v", expected, values)
+	}
+}
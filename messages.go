@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// MessageHandler receives the messages the server sends in response to
+// a single call, e.g. from print, raiserror, set statistics io or
+// showplan.
+//
+// statement is the zero-based index of the statement of the batch that
+// produced the message. Statements executed by stored procedures are
+// counted as statements of the batch.
+type MessageHandler func(statement int, eed tds.EEDPackage)
+
+type messageHandlerKey struct{}
+
+// WithMessageHandler returns a context passing the messages of calls to
+// QueryContext, ExecContext or BeginTx with the context to handler.
+//
+// Unlike EEDHooks, which receive the messages of all commands of
+// a connection, the handler only receives the messages belonging to the
+// call, in the order they were sent. Messages of queries are passed
+// until the rows are closed.
+//
+// The handler is called on the goroutine executing the call while the
+// connection is locked, it must not use the connection.
+func WithMessageHandler(ctx context.Context, handler MessageHandler) context.Context {
+	return context.WithValue(ctx, messageHandlerKey{}, handler)
+}
+
+// messageHandler returns the MessageHandler of the context or nil.
+func messageHandler(ctx context.Context) MessageHandler {
+	handler, _ := ctx.Value(messageHandlerKey{}).(MessageHandler)
+	return handler
+}
+
+// messages passes the messages of the current call of a connection to
+// its MessageHandler.
+type messages struct {
+	handler MessageHandler
+	// statement is the index of the statement in the batch that is
+	// currently being processed.
+	statement int
+}
+
+// handleMessagePackage passes EEDs to the MessageHandler of the current
+// call and tracks the statements of the batch.
+func (c *Conn) handleMessagePackage(pkg tds.Package) {
+	if c.messages.handler == nil {
+		return
+	}
+
+	switch typed := pkg.(type) {
+	case *tds.EEDPackage:
+		c.messages.handler(c.messages.statement, *typed)
+	case *tds.DonePackage:
+		if typed.Status&tds.TDS_DONE_MORE == tds.TDS_DONE_MORE {
+			c.messages.statement++
+		}
+	}
+}
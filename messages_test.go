@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// newPrintServer returns a server responding to each print statement
+// of a batch with a message.
+func newPrintServer(t *testing.T) *testServer {
+	server := newTestServer(t)
+
+	server.HandleFunc("", func(req asetest.Request) []asetest.Result {
+		var results []asetest.Result
+		for _, stmt := range strings.Split(req.Query, ";") {
+			stmt = strings.TrimSpace(stmt)
+
+			var result asetest.Result
+			switch {
+			case strings.HasPrefix(stmt, "print "):
+				result.Messages = []asetest.Message{{Text: strings.Trim(strings.TrimPrefix(stmt, "print "), "'")}}
+			case strings.HasPrefix(stmt, "raiserror "):
+				result.Error = &asetest.Message{Number: 20000, Severity: 16, Text: "failed"}
+				return append(results, result)
+			case strings.HasPrefix(stmt, "select "):
+				result.Columns = []asetest.Column{{Name: "value"}}
+				result.Rows = [][]interface{}{{int32(1)}}
+			}
+
+			results = append(results, result)
+		}
+		return results
+	})
+
+	return server
+}
+
+// messageRecorder records the messages passed to its handler.
+type messageRecorder []string
+
+func (recorder *messageRecorder) handle(statement int, eed tds.EEDPackage) {
+	*recorder = append(*recorder, fmt.Sprintf("%d: %s", statement, eed.Msg))
+}
+
+func TestMessageHandlerExec(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect()
+
+	var recorder messageRecorder
+	ctx := WithMessageHandler(context.Background(), recorder.handle)
+
+	if _, err := conn.ExecContext(ctx, "print 'a'; print 'b'; print 'c'", nil); err != nil {
+		t.Fatalf("error executing batch: %v", err)
+	}
+
+	// Messages of calls without the context are not passed to the
+	// handler.
+	if _, err := conn.ExecContext(context.Background(), "print 'd'", nil); err != nil {
+		t.Fatalf("error executing batch: %v", err)
+	}
+
+	expected := messageRecorder{"0: a", "1: b", "2: c"}
+	if !reflect.DeepEqual(recorder, expected) {
+		t.Errorf("unexpected messages:\nexpected: %q\nreceived: %q", expected, recorder)
+	}
+}
+
+func TestMessageHandlerError(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect()
+
+	var recorder messageRecorder
+	ctx := WithMessageHandler(context.Background(), recorder.handle)
+
+	if _, err := conn.ExecContext(ctx, "print 'a'; raiserror 20000 'failed'", nil); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	expected := messageRecorder{"0: a", "1: failed"}
+	if !reflect.DeepEqual(recorder, expected) {
+		t.Errorf("unexpected messages:\nexpected: %q\nreceived: %q", expected, recorder)
+	}
+}
+
+func TestMessageHandlerQuery(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.NoQueryCursor = true
+		return nil
+	})
+
+	var recorder messageRecorder
+	ctx := WithMessageHandler(context.Background(), recorder.handle)
+
+	rows, err := conn.QueryContext(ctx, "print 'a'; select 1; print 'b'", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+
+	values := make([]driver.Value, 1)
+	if err := rows.Next(values); err != nil {
+		t.Fatalf("error reading row: %v", err)
+	}
+
+	if err := rows.Next(values); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Fatalf("error closing rows: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "print 'c'", nil); err != nil {
+		t.Fatalf("error executing batch: %v", err)
+	}
+
+	expected := messageRecorder{"0: a", "2: b"}
+	if !reflect.DeepEqual(recorder, expected) {
+		t.Errorf("unexpected messages:\nexpected: %q\nreceived: %q", expected, recorder)
+	}
+}
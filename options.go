@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Option is a session option that can be set and retrieved with
+// Conn.SetOption and Conn.GetOption.
+//
+// The type of the value of an option is documented with each option.
+type Option tds.OptionCmdOption
+
+// Session options.
+const (
+	// OptionRowCount limits the number of rows affected by statements,
+	// 0 disables the limit. Value type: int.
+	OptionRowCount = Option(tds.TDS_OPT_ROWCOUNT)
+	// OptionTextSize sets the maximum number of bytes returned for
+	// text and image columns. Value type: int.
+	OptionTextSize = Option(tds.TDS_OPT_TEXTSIZE)
+	// OptionDateFirst sets the first day of the week.
+	// Value type: time.Weekday.
+	OptionDateFirst = Option(tds.TDS_OPT_DATEFIRST)
+	// OptionDateFormat sets the order of date parts when parsing
+	// dates. Value type: DateFormat.
+	OptionDateFormat = Option(tds.TDS_OPT_DATEFORMAT)
+	// OptionArithAbort sets the arithmetic errors aborting
+	// a statement. Value type: ArithAbort.
+	OptionArithAbort = Option(tds.TDS_OPT_ARITHABORTON)
+	// OptionNoCount prevents the server from sending the number of
+	// affected rows. Value type: bool.
+	OptionNoCount = Option(tds.TDS_OPT_NOCOUNT)
+	// OptionChained enables the chained transaction mode.
+	// Value type: bool.
+	OptionChained = Option(tds.TDS_OPT_CHAINXACTS)
+	// OptionQuotedIdentifier enables quoted identifiers.
+	// Value type: bool.
+	OptionQuotedIdentifier = Option(tds.TDS_OPT_QUOTED_IDENT)
+	// OptionStatisticsIO enables the reporting of I/O statistics.
+	// Value type: bool.
+	OptionStatisticsIO = Option(tds.TDS_OPT_STAT_IO)
+	// OptionStatisticsTime enables the reporting of time statistics.
+	// Value type: bool.
+	OptionStatisticsTime = Option(tds.TDS_OPT_STAT_TIME)
+	// OptionShowplan enables the reporting of query plans.
+	// Value type: bool.
+	OptionShowplan = Option(tds.TDS_OPT_SHOWPLAN)
+	// OptionIsolation sets the transaction isolation level.
+	// Value type: sql.IsolationLevel.
+	OptionIsolation = Option(tds.TDS_OPT_ISOLATION)
+)
+
+func (opt Option) String() string {
+	return tds.OptionCmdOption(opt).String()
+}
+
+// supported returns true if the value type of the option is known.
+func (opt Option) supported() bool {
+	switch opt {
+	case OptionRowCount, OptionTextSize, OptionDateFirst, OptionDateFormat,
+		OptionArithAbort, OptionNoCount, OptionChained, OptionQuotedIdentifier,
+		OptionStatisticsIO, OptionStatisticsTime, OptionShowplan, OptionIsolation:
+		return true
+	}
+	return false
+}
+
+// DateFormat is the order of date parts, see OptionDateFormat.
+type DateFormat uint8
+
+// Date formats.
+const (
+	DateFormatMDY DateFormat = iota + 1
+	DateFormatDMY
+	DateFormatYMD
+	DateFormatYDM
+	DateFormatMYD
+	DateFormatDYM
+)
+
+// ArithAbort is a bitmask of arithmetic errors aborting a statement,
+// see OptionArithAbort.
+type ArithAbort uint8
+
+// Arithmetic errors.
+const (
+	ArithAbortOverflow          ArithAbort = 0x01
+	ArithAbortNumericTruncation ArithAbort = 0x02
+)
+
+// ErrUnsupportedOption is returned by SetOption and GetOption for
+// options without a known value type.
+var ErrUnsupportedOption = errors.New("go-ase: unsupported option")
+
+// encodeOption returns the argument of an option command for value.
+func encodeOption(opt Option, value interface{}) ([]byte, error) {
+	switch opt {
+	case OptionRowCount, OptionTextSize:
+		i, ok := value.(int)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type int, got %T", opt, value)
+		}
+		if i < 0 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("go-ase: value %d for option %s is out of range", i, opt)
+		}
+		return binary.LittleEndian.AppendUint32(nil, uint32(i)), nil
+	case OptionDateFirst:
+		weekday, ok := value.(time.Weekday)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type time.Weekday, got %T", opt, value)
+		}
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return nil, fmt.Errorf("go-ase: invalid weekday %d for option %s", weekday, opt)
+		}
+		// ASE counts from Monday (1) to Sunday (7).
+		return []byte{byte((weekday+6)%7 + 1)}, nil
+	case OptionDateFormat:
+		format, ok := value.(DateFormat)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type DateFormat, got %T", opt, value)
+		}
+		if format < DateFormatMDY || format > DateFormatDYM {
+			return nil, fmt.Errorf("go-ase: invalid date format %d for option %s", format, opt)
+		}
+		return []byte{byte(format)}, nil
+	case OptionArithAbort:
+		arithAbort, ok := value.(ArithAbort)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type ArithAbort, got %T", opt, value)
+		}
+		return []byte{byte(arithAbort)}, nil
+	case OptionNoCount, OptionChained, OptionQuotedIdentifier,
+		OptionStatisticsIO, OptionStatisticsTime, OptionShowplan:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type bool, got %T", opt, value)
+		}
+		if b {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case OptionIsolation:
+		level, ok := value.(sql.IsolationLevel)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: option %s requires value of type sql.IsolationLevel, got %T", opt, value)
+		}
+		aseLevel, err := aseIsolationLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		return []byte{aseLevel}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedOption, opt)
+}
+
+// decodeOption returns the value of an option from the argument of an
+// option command.
+func decodeOption(opt Option, arg []byte) (interface{}, error) {
+	switch opt {
+	case OptionRowCount, OptionTextSize:
+		if len(arg) != 4 {
+			return nil, fmt.Errorf("go-ase: expected 4 bytes for option %s, got %d", opt, len(arg))
+		}
+		return int(int32(binary.LittleEndian.Uint32(arg))), nil
+	}
+
+	if len(arg) != 1 {
+		return nil, fmt.Errorf("go-ase: expected 1 byte for option %s, got %d", opt, len(arg))
+	}
+
+	switch opt {
+	case OptionDateFirst:
+		if arg[0] < 1 || arg[0] > 7 {
+			return nil, fmt.Errorf("go-ase: invalid value %d for option %s", arg[0], opt)
+		}
+		return time.Weekday(arg[0] % 7), nil
+	case OptionDateFormat:
+		return DateFormat(arg[0]), nil
+	case OptionArithAbort:
+		return ArithAbort(arg[0]), nil
+	case OptionNoCount, OptionChained, OptionQuotedIdentifier,
+		OptionStatisticsIO, OptionStatisticsTime, OptionShowplan:
+		return arg[0] != 0, nil
+	case OptionIsolation:
+		return isolationLevelFromASE(arg[0])
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedOption, opt)
+}
+
+// SetOption sets a session option to value.
+//
+// The type of value must match the type documented for the option.
+// An error is returned if the server does not acknowledge the option.
+func (c *Conn) SetOption(ctx context.Context, opt Option, value interface{}) error {
+	arg, err := encodeOption(opt, value)
+	if err != nil {
+		return err
+	}
+
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+
+	pkg := &tds.OptionCmdPackage{
+		Cmd:       tds.TDS_OPT_SET,
+		Option:    tds.OptionCmdOption(opt),
+		OptionArg: arg,
+	}
+
+	c.stats.roundTrip()
+	if err := c.channel.SendPackage(ctx, pkg); err != nil {
+		return fmt.Errorf("go-ase: error sending option %s: %w", opt, err)
+	}
+
+	if err := c.optionAck(ctx); err != nil {
+		return fmt.Errorf("go-ase: error setting option %s: %w", opt, err)
+	}
+
+	if opt == OptionChained {
+		c.chained = value.(bool)
+	}
+
+	return nil
+}
+
+// optionAck consumes the response to an option command and returns an
+// error if the server did not acknowledge the command.
+func (c *Conn) optionAck(ctx context.Context) error {
+	_, err := c.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+		done, ok := pkg.(*tds.DonePackage)
+		if !ok {
+			return false, nil
+		}
+		return c.handleDonePackage(done)
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// GetOption returns the current value of a session option as reported
+// by the server.
+//
+// The value is requested with a TDS_OPT_LIST option command, which the
+// server answers with the current argument of the option.
+//
+// The type of the returned value is documented with each option.
+func (c *Conn) GetOption(ctx context.Context, opt Option) (interface{}, error) {
+	if !opt.supported() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedOption, opt)
+	}
+
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	pkg := &tds.OptionCmdPackage{
+		Cmd:    tds.TDS_OPT_LIST,
+		Option: tds.OptionCmdOption(opt),
+	}
+
+	c.stats.roundTrip()
+	if err := c.channel.SendPackage(ctx, pkg); err != nil {
+		return nil, fmt.Errorf("go-ase: error sending option %s: %w", opt, err)
+	}
+
+	var arg []byte
+	var found bool
+	_, err := c.nextPackageUntil(ctx, true, func(pkg tds.Package) (bool, error) {
+		switch typed := pkg.(type) {
+		case *tds.OptionCmdPackage:
+			if typed.Cmd == tds.TDS_OPT_INFO && typed.Option == tds.OptionCmdOption(opt) {
+				arg, found = typed.OptionArg, true
+			}
+		case *tds.DonePackage:
+			return c.handleDonePackage(typed)
+		}
+		return false, nil
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("go-ase: error retrieving option %s: %w", opt, err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("go-ase: server did not report value of option %s", opt)
+	}
+
+	value, err := decodeOption(opt, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt == OptionChained {
+		c.chained = value.(bool)
+	}
+
+	return value, nil
+}
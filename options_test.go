@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+func TestOptionEncoding(t *testing.T) {
+	cases := []struct {
+		opt   Option
+		value interface{}
+		arg   []byte
+	}{
+		{OptionRowCount, 100, []byte{100, 0, 0, 0}},
+		{OptionTextSize, 65536, []byte{0, 0, 1, 0}},
+		{OptionDateFirst, time.Monday, []byte{1}},
+		{OptionDateFirst, time.Sunday, []byte{7}},
+		{OptionDateFormat, DateFormatYMD, []byte{3}},
+		{OptionArithAbort, ArithAbortOverflow | ArithAbortNumericTruncation, []byte{3}},
+		{OptionNoCount, true, []byte{1}},
+		{OptionQuotedIdentifier, false, []byte{0}},
+		{OptionIsolation, sql.LevelReadUncommitted, []byte{0}},
+		{OptionIsolation, sql.LevelSerializable, []byte{3}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.opt.String(), func(t *testing.T) {
+			arg, err := encodeOption(tc.opt, tc.value)
+			if err != nil {
+				t.Fatalf("error encoding: %v", err)
+			}
+
+			if !reflect.DeepEqual(arg, tc.arg) {
+				t.Errorf("expected argument %v, got %v", tc.arg, arg)
+			}
+
+			value, err := decodeOption(tc.opt, arg)
+			if err != nil {
+				t.Fatalf("error decoding: %v", err)
+			}
+
+			if value != tc.value {
+				t.Errorf("expected value %v, got %v", tc.value, value)
+			}
+		})
+	}
+}
+
+func TestOptionEncodingErrors(t *testing.T) {
+	if _, err := encodeOption(OptionNoCount, 1); err == nil {
+		t.Errorf("expected error for value of wrong type")
+	}
+
+	if _, err := encodeOption(OptionRowCount, -1); err == nil {
+		t.Errorf("expected error for value out of range")
+	}
+
+	if _, err := encodeOption(OptionIsolation, sql.LevelSnapshot); err == nil {
+		t.Errorf("expected error for isolation level without ASE equivalent")
+	}
+
+	if _, err := encodeOption(Option(tds.TDS_OPT_NATLANG), "us_english"); !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("expected ErrUnsupportedOption, got: %v", err)
+	}
+}
+
+func TestGetOptionUnsupported(t *testing.T) {
+	server := newTestServer(t)
+	conn := server.connect()
+
+	if _, err := conn.GetOption(context.Background(), Option(tds.TDS_OPT_NATLANG)); !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("expected ErrUnsupportedOption, got: %v", err)
+	}
+
+	if commands := server.commands(); len(commands) != 0 {
+		t.Errorf("expected no commands, received: %q", commands)
+	}
+}
+
+func TestGetOption(t *testing.T) {
+	cases := map[Option]struct {
+		initial, value interface{}
+	}{
+		OptionRowCount:         {0, 10},
+		OptionTextSize:         {32768, 1024},
+		OptionDateFirst:        {time.Sunday, time.Monday},
+		OptionDateFormat:       {DateFormatMDY, DateFormatYMD},
+		OptionArithAbort:       {ArithAbortOverflow, ArithAbortOverflow | ArithAbortNumericTruncation},
+		OptionNoCount:          {false, true},
+		OptionChained:          {false, true},
+		OptionQuotedIdentifier: {false, true},
+		OptionStatisticsIO:     {false, true},
+		OptionStatisticsTime:   {false, true},
+		OptionShowplan:         {false, true},
+		OptionIsolation:        {sql.LevelReadCommitted, sql.LevelSerializable},
+	}
+
+	server := newTestServer(t)
+	conn := server.connect()
+
+	for opt, c := range cases {
+		t.Run(opt.String(), func(t *testing.T) {
+			for _, expected := range []interface{}{c.initial, c.value} {
+				if expected != c.initial {
+					if err := conn.SetOption(context.Background(), opt, expected); err != nil {
+						t.Fatalf("error setting option: %v", err)
+					}
+				}
+
+				value, err := conn.GetOption(context.Background(), opt)
+				if err != nil {
+					t.Fatalf("error retrieving option: %v", err)
+				}
+
+				if value != expected {
+					t.Errorf("expected %v, got %v", expected, value)
+				}
+			}
+		})
+	}
+
+	if !conn.Chained() {
+		t.Errorf("expected connection in chained mode after retrieving the option")
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// loginRecorder records the EEDPackages received during the login
+// negotiation.
+type loginRecorder struct {
+	sync.Mutex
+	active bool
+	eeds   []tds.EEDPackage
+}
+
+// hook is registered as tds.EEDHook on the channel before logging in.
+func (rec *loginRecorder) hook(eed tds.EEDPackage) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.active {
+		rec.eeds = append(rec.eeds, eed)
+	}
+}
+
+// stop stops the recording and returns the recorded packages.
+func (rec *loginRecorder) stop() []tds.EEDPackage {
+	rec.Lock()
+	defer rec.Unlock()
+
+	rec.active = false
+	return rec.eeds
+}
+
+// passwordExpired returns the EEDPackage signaling an expired
+// password, if one was received.
+func passwordExpired(eeds []tds.EEDPackage) (tds.EEDPackage, bool) {
+	for _, eed := range eeds {
+		if eed.MsgNumber == msgPasswordExpired {
+			return eed, true
+		}
+	}
+
+	return tds.EEDPackage{}, false
+}
+
+// LoginMessages returns the messages sent by the server during the
+// login negotiation, e.g. database and language changes or password
+// expiry warnings.
+func (c *Conn) LoginMessages() []tds.EEDPackage {
+	return c.loginMessages
+}
+
+// PasswordExpiryWarning returns the warning sent by ASE if the
+// password of the login is about to expire.
+func (c *Conn) PasswordExpiryWarning() (tds.EEDPackage, bool) {
+	for _, eed := range c.loginMessages {
+		if eed.MsgNumber == msgPasswordExpiresSoon {
+			return eed, true
+		}
+	}
+
+	return tds.EEDPackage{}, false
+}
+
+// ChangePassword logs in with the credentials in info, changes the
+// password of the login to newPassword and returns a connection opened
+// with the new password.
+//
+// ChangePassword works with expired passwords, in which case ASE
+// permits the login only to change the password.
+//
+// The passed info is not modified, the returned Conn uses a copy of
+// info with the new password. Connections requiring the options of
+// a Connector, e.g. a Dialer, change the password with
+// Connector.ChangePassword.
+func ChangePassword(ctx context.Context, info *Info, newPassword string) (*Conn, error) {
+	newInfo := *info
+	newInfo.Password = newPassword
+
+	connector := &Connector{Info: &newInfo}
+	return connector.changePassword(ctx, info.Password, newPassword)
+}
+
+// ChangePassword logs in with the password of the connector, changes
+// the password of the login to newPassword and returns a connection
+// opened with the new password.
+//
+// The password is read from the CredentialsProvider if set, otherwise
+// from Info.Password. Both connections are opened with the options of
+// the connector, e.g. its Dialer and TLSConfig.
+//
+// ChangePassword works with expired passwords, in which case ASE
+// permits the login only to change the password.
+//
+// The connector is not modified, its Info or CredentialsProvider must
+// be updated with the new password before further connections are
+// opened.
+func (c *Connector) ChangePassword(ctx context.Context, newPassword string) (*Conn, error) {
+	password := c.Info.Password
+	if c.CredentialsProvider != nil {
+		var err error
+		if password, err = c.CredentialsProvider.Password(ctx, false); err != nil {
+			return nil, fmt.Errorf("go-ase: error retrieving password from credentials provider: %w", err)
+		}
+	}
+
+	return c.changePassword(ctx, password, newPassword)
+}
+
+// changePassword changes the password of the login of the connector
+// from oldPassword to newPassword and returns a connection opened with
+// the new password.
+func (c *Connector) changePassword(ctx context.Context, oldPassword, newPassword string) (*Conn, error) {
+	// ASE only allows sp_password in sessions with expired passwords,
+	// hence the session must not be set up: the database is not
+	// switched, no init statements are executed and no transaction
+	// branches are recovered.
+	changeInfo := *c.Info
+	changeInfo.Database = ""
+	changeInfo.InitSQL = ""
+
+	changeConnector := *c
+	changeConnector.Info = &changeInfo
+	changeConnector.InitStatements = nil
+	changeConnector.XARecoveryHandler = nil
+	changeConnector.allowExpiredPassword = true
+
+	conn, err := changeConnector.newConn(ctx, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("exec sp_password %s, %s", quoteString(oldPassword), quoteString(newPassword))
+	if _, err := conn.ExecContext(ctx, query, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("go-ase: error changing password: %w", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		return nil, err
+	}
+
+	conn, err = c.newConn(ctx, newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error connecting with changed password: %w", err)
+	}
+
+	return conn, nil
+}
+
+// quoteString returns s as a quoted string literal.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
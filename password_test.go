@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// expiringServer is a server with a single login whose password can be
+// expired and changed with sp_password.
+type expiringServer struct {
+	*testServer
+
+	lock     sync.Mutex
+	password string
+	expired  bool
+}
+
+func newExpiringServer(t *testing.T, password string) *expiringServer {
+	t.Helper()
+
+	server := &expiringServer{testServer: newTestServer(t), password: password, expired: true}
+	server.HandleLogin(server.login)
+	server.HandleFunc("", func(req asetest.Request) []asetest.Result {
+		return []asetest.Result{{}}
+	})
+
+	return server
+}
+
+func (s *expiringServer) login(login asetest.Login) []asetest.Message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch {
+	case login.Password != s.password:
+		return []asetest.Message{{Number: msgLoginFailed, Severity: 14, Text: "Login failed."}}
+	case s.expired:
+		return []asetest.Message{{Number: msgPasswordExpired, Severity: 10, Text: "Your password has expired."}}
+	}
+
+	return nil
+}
+
+// changePassword answers sp_password, which changes the password from
+// old to new.
+func (s *expiringServer) changePassword(old, new string) {
+	s.HandleFunc("exec sp_password "+quoteString(old)+", "+quoteString(new), func(req asetest.Request) []asetest.Result {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		s.password, s.expired = new, false
+		return []asetest.Result{{}}
+	})
+}
+
+func (s *expiringServer) info(password string) *Info {
+	s.t.Helper()
+
+	info, err := NewInfo()
+	if err != nil {
+		s.t.Fatalf("error creating info: %v", err)
+	}
+	info.Host, info.Port = s.Host(), s.Port()
+	info.Username, info.Password = "user", password
+
+	return info
+}
+
+func TestPasswordExpired(t *testing.T) {
+	server := newExpiringServer(t, "old")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := NewConn(ctx, server.info("old"))
+	if !errors.Is(err, ErrPasswordExpired) {
+		t.Fatalf("expected ErrPasswordExpired, got: %v", err)
+	}
+
+	var expiredErr *PasswordExpiredError
+	if !errors.As(err, &expiredErr) {
+		t.Fatalf("expected *PasswordExpiredError, got: %T", err)
+	}
+
+	if expiredErr.EED.MsgNumber != msgPasswordExpired {
+		t.Errorf("expected message %d, got: %d", msgPasswordExpired, expiredErr.EED.MsgNumber)
+	}
+
+	if expiredErr.Err != nil {
+		t.Errorf("unexpected error of login: %v", expiredErr.Err)
+	}
+
+	// No statement is executed in a session with an expired password.
+	if commands := server.commands(); len(commands) != 0 {
+		t.Errorf("unexpected commands: %q", commands)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	server := newExpiringServer(t, "old")
+	server.changePassword("old", "n'ew")
+
+	info := server.info("old")
+	info.Database = "db"
+	info.InitSQL = "set textsize 1024"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := ChangePassword(ctx, info, "n'ew")
+	if err != nil {
+		t.Fatalf("error changing password: %v", err)
+	}
+	defer conn.Close()
+
+	if info.Password != "old" {
+		t.Errorf("password of passed info changed to %q", info.Password)
+	}
+
+	if conn.Info.Password != "n'ew" {
+		t.Errorf("expected connection with new password, got: %q", conn.Info.Password)
+	}
+
+	// The session of the expired password only changes the password,
+	// the session of the new password is set up.
+	expected := []string{
+		"exec sp_password 'old', 'n''ew'",
+		"use db",
+		"set textsize 1024",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected commands %q, got %q", expected, commands)
+	}
+}
+
+func TestConnectorChangePassword(t *testing.T) {
+	server := newExpiringServer(t, "old")
+	server.changePassword("old", "new")
+
+	var dials int
+	connector := server.connector(
+		WithDialer(DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			dials++
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		})),
+		WithCredentialsProvider(PasswordFunc(func(ctx context.Context, refresh bool) (string, error) {
+			return "old", nil
+		})),
+		WithInitStatements("set textsize 1024"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := connector.ChangePassword(ctx, "new")
+	if err != nil {
+		t.Fatalf("error changing password: %v", err)
+	}
+	defer conn.Close()
+
+	// Both connections are opened through the dialer of the connector.
+	if dials != 2 {
+		t.Errorf("expected 2 connections through the dialer, got %d", dials)
+	}
+
+	expected := []string{
+		"exec sp_password 'old', 'new'",
+		"set textsize 1024",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected commands %q, got %q", expected, commands)
+	}
+
+	if connector.Info.Password != "" {
+		t.Errorf("password leaked into info: %q", connector.Info.Password)
+	}
+}
+
+func TestChangePasswordWrongPassword(t *testing.T) {
+	server := newExpiringServer(t, "old")
+	server.changePassword("old", "new")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := ChangePassword(ctx, server.info("wrong"), "new")
+	if number, ok := MessageNumber(err); !ok || number != msgLoginFailed {
+		t.Errorf("expected login to fail with message %d, got: %v", msgLoginFailed, err)
+	}
+
+	if commands := server.commands(); len(commands) != 0 {
+		t.Errorf("unexpected commands: %q", commands)
+	}
+}
+
+func TestPasswordExpiryWarning(t *testing.T) {
+	server := newTestServer(t)
+	server.HandleLogin(func(login asetest.Login) []asetest.Message {
+		return []asetest.Message{{Number: msgPasswordExpiresSoon, Severity: 10, Text: "Your password will expire in 3 days."}}
+	})
+
+	conn := server.connect()
+
+	eed, ok := conn.PasswordExpiryWarning()
+	if !ok {
+		t.Fatalf("expected password expiry warning, got login messages: %v", conn.LoginMessages())
+	}
+
+	if eed.Msg != "Your password will expire in 3 days." {
+		t.Errorf("unexpected warning: %q", eed.Msg)
+	}
+}
+
+func TestPasswordExpiredError(t *testing.T) {
+	eed := tds.EEDPackage{MsgNumber: msgPasswordExpired, Msg: "Your password has expired."}
+
+	err := error(&PasswordExpiredError{EED: eed, Err: io.EOF})
+	if !errors.Is(err, ErrPasswordExpired) {
+		t.Errorf("expected error to match ErrPasswordExpired")
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("expected error to wrap the login error")
+	}
+
+	expected := "go-ase: password expired: 4022: Your password has expired.: EOF"
+	if err.Error() != expected {
+		t.Errorf("unexpected error message:\nexpected: %s\nreceived: %s", expected, err)
+	}
+
+	var expiredError *PasswordExpiredError
+	if !errors.As(err, &expiredError) || expiredError.EED.MsgNumber != msgPasswordExpired {
+		t.Errorf("expected PasswordExpiredError with message %d", msgPasswordExpired)
+	}
+}
+
+func TestLoginRecorder(t *testing.T) {
+	recorder := &loginRecorder{active: true}
+	recorder.hook(tds.EEDPackage{MsgNumber: 5701})
+	recorder.hook(tds.EEDPackage{MsgNumber: msgPasswordExpired})
+
+	eeds := recorder.stop()
+
+	// Messages after the login are not recorded.
+	recorder.hook(tds.EEDPackage{MsgNumber: 5703})
+
+	expected := []tds.EEDPackage{{MsgNumber: 5701}, {MsgNumber: msgPasswordExpired}}
+	if !reflect.DeepEqual(eeds, expected) {
+		t.Errorf("unexpected recorded messages:\nexpected: %v\nreceived: %v", expected, eeds)
+	}
+
+	if eed, ok := passwordExpired(eeds); !ok || eed.MsgNumber != msgPasswordExpired {
+		t.Errorf("expected message %d to signal an expired password", msgPasswordExpired)
+	}
+
+	if _, ok := passwordExpired(eeds[:1]); ok {
+		t.Errorf("unexpected expired password without message %d", msgPasswordExpired)
+	}
+}
+
+func TestPasswordExpiryWarningMessages(t *testing.T) {
+	conn := &Conn{loginMessages: []tds.EEDPackage{{MsgNumber: 5701}}}
+	if _, ok := conn.PasswordExpiryWarning(); ok {
+		t.Errorf("unexpected password expiry warning")
+	}
+
+	conn.loginMessages = append(conn.loginMessages, tds.EEDPackage{MsgNumber: msgPasswordExpiresSoon})
+	if eed, ok := conn.PasswordExpiryWarning(); !ok || eed.MsgNumber != msgPasswordExpiresSoon {
+		t.Errorf("expected password expiry warning with message %d", msgPasswordExpiresSoon)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	for s, expected := range map[string]string{
+		"secret":   "'secret'",
+		"it's":     "'it''s'",
+		"'quoted'": "'''quoted'''",
+	} {
+		if quoted := quoteString(s); quoted != expected {
+			t.Errorf("expected %s to be quoted as %s, got %s", s, expected, quoted)
+		}
+	}
+}
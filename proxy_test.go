@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+)
+
+// listen starts a listener on the loopback interface and passes
+// accepted connections to handle.
+func listen(t *testing.T, handle func(net.Conn)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error opening listener: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func echo(conn net.Conn) {
+	defer conn.Close()
+	_, _ = io.Copy(conn, conn)
+}
+
+// socks5Server is a minimal SOCKS5 server accepting the credentials
+// user:pass.
+func socks5Server(conn net.Conn) {
+	defer conn.Close()
+
+	buf := make([]byte, 512)
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, buf[:buf[1]]); err != nil {
+		return
+	}
+	_, _ = conn.Write([]byte{socks5Version, socks5AuthPassword})
+
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return
+	}
+	username := make([]byte, buf[1])
+	_, _ = io.ReadFull(conn, username)
+	_, _ = io.ReadFull(conn, buf[:1])
+	password := make([]byte, buf[0])
+	_, _ = io.ReadFull(conn, password)
+	if string(username) != "user" || string(password) != "pass" {
+		_, _ = conn.Write([]byte{socks5AuthVersion, 0x01})
+		return
+	}
+	_, _ = conn.Write([]byte{socks5AuthVersion, 0x00})
+
+	if _, err := io.ReadFull(conn, buf[:4]); err != nil || buf[3] != socks5AddrDomain {
+		return
+	}
+	_, _ = io.ReadFull(conn, buf[:1])
+	host := make([]byte, buf[0])
+	_, _ = io.ReadFull(conn, host)
+	_, _ = io.ReadFull(conn, buf[:2])
+	port := binary.BigEndian.Uint16(buf[:2])
+
+	target, err := net.Dial("tcp", net.JoinHostPort(string(host), strconv.Itoa(int(port))))
+	if err != nil {
+		_, _ = conn.Write([]byte{socks5Version, 0x05, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	_, _ = conn.Write([]byte{socks5Version, socks5ReplySucceded, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+
+	relay(conn, target)
+}
+
+func httpConnectServer(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	req, err := http.ReadRequest(reader)
+	if err != nil || req.Method != http.MethodConnect {
+		conn.Close()
+		return
+	}
+
+	target, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+	relay(conn, target)
+}
+
+// relay copies data between a and b until either is closed and closes
+// both afterwards.
+func relay(a, b net.Conn) {
+	closeBoth := &sync.Once{}
+	closeFn := func() {
+		a.Close()
+		b.Close()
+	}
+
+	go func() {
+		_, _ = io.Copy(a, b)
+		closeBoth.Do(closeFn)
+	}()
+
+	_, _ = io.Copy(b, a)
+	closeBoth.Do(closeFn)
+}
+
+func TestProxyDialer(t *testing.T) {
+	target := listen(t, echo)
+	_, port, _ := net.SplitHostPort(target)
+	// The SOCKS5 server only supports domain names.
+	target = net.JoinHostPort("localhost", port)
+
+	cases := map[string]string{
+		"socks5": "socks5://user:pass@" + listen(t, socks5Server),
+		"http":   "http://" + listen(t, httpConnectServer),
+	}
+
+	for name, proxyURL := range cases {
+		t.Run(name, func(t *testing.T) {
+			dialer, err := newProxyDialer(proxyURL, &net.Dialer{})
+			if err != nil {
+				t.Fatalf("error creating proxy dialer: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			conn, err := dialer.DialContext(ctx, "tcp", target)
+			if err != nil {
+				t.Fatalf("error dialing through proxy: %v", err)
+			}
+			defer conn.Close()
+
+			if _, err := conn.Write([]byte("ping")); err != nil {
+				t.Fatalf("error writing through proxy: %v", err)
+			}
+
+			buf := make([]byte, 4)
+			if _, err := io.ReadFull(conn, buf); err != nil {
+				t.Fatalf("error reading through proxy: %v", err)
+			}
+
+			if string(buf) != "ping" {
+				t.Errorf("expected echo 'ping', got %q", buf)
+			}
+		})
+	}
+}
+
+func TestProxyDialerRejectedCredentials(t *testing.T) {
+	dialer, err := newProxyDialer("socks5://user:wrong@"+listen(t, socks5Server), &net.Dialer{})
+	if err != nil {
+		t.Fatalf("error creating proxy dialer: %v", err)
+	}
+
+	if _, err := dialer.DialContext(context.Background(), "tcp", "localhost:4901"); err == nil {
+		t.Errorf("expected error for rejected credentials")
+	}
+}
+
+func TestProxyDialerCancelAfterDial(t *testing.T) {
+	target := listen(t, echo)
+	dialer, err := newProxyDialer("http://"+listen(t, httpConnectServer), &net.Dialer{})
+	if err != nil {
+		t.Fatalf("error creating proxy dialer: %v", err)
+	}
+
+	// Cancelling the context after the dial must not set a deadline on
+	// the connection.
+	for i := 0; i < 20; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		cancel()
+		if err != nil {
+			t.Fatalf("error dialing through proxy: %v", err)
+		}
+
+		if _, err := conn.Write([]byte("ping")); err != nil {
+			t.Fatalf("error writing through proxy: %v", err)
+		}
+
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			t.Fatalf("error reading through proxy: %v", err)
+		}
+		conn.Close()
+	}
+}
+
+func TestConnectorProxy(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("select 'ping'", asetest.Result{})
+
+	cases := map[string]string{
+		"socks5": "socks5://user:pass@" + listen(t, socks5Server),
+		"http":   "http://" + listen(t, httpConnectServer),
+	}
+
+	for name, proxyURL := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn := server.connect(func(c *Connector) error {
+				// The SOCKS5 server only supports domain names.
+				c.Info.Host = "localhost"
+				c.Info.Proxy = proxyURL
+				return nil
+			})
+
+			if err := conn.Ping(context.Background()); err != nil {
+				t.Errorf("error pinging through proxy: %v", err)
+			}
+		})
+	}
+}
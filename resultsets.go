@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// WithMultipleResultSets permits commands on a connection while rows
+// are open, so that multiple Rows, CursorRows and statements can be
+// active at the same time on one connection.
+//
+// When a command is sent while the rows of a previous query occupy the
+// channel of the connection, the remaining response of the query is
+// read into memory first and the rows are read from memory afterwards.
+//
+// The exchanges are not multiplexed: only one response is read from
+// the channel at a time, the response of the rows is read into memory
+// completely before the command is sent.
+//
+// At most DefaultMaxBufferedRows (10000) rows of a response are read
+// into memory, which can be changed with WithMaxBufferedRows. If the
+// remaining response is longer the command fails with ErrResultSetOpen
+// and the rows continue to occupy the channel until they are read or
+// closed. Queries returning large result sets should be read through
+// cursors, whose rows are fetched in chunks and do not occupy the
+// channel.
+//
+// Without the option commands sent while rows are open fail with
+// ErrResultSetOpen.
+func WithMultipleResultSets() ConnectorOption {
+	return func(c *Connector) error {
+		c.MultipleResultSets = true
+		return nil
+	}
+}
+
+// DefaultMaxBufferedRows is the maximum number of rows of a response
+// read into memory for WithMultipleResultSets unless set with
+// WithMaxBufferedRows.
+const DefaultMaxBufferedRows = 10000
+
+// WithMaxBufferedRows sets the maximum number of rows of a response
+// that are read into memory when a command is sent while the rows
+// occupy the channel, see WithMultipleResultSets.
+func WithMaxBufferedRows(n int) ConnectorOption {
+	return func(c *Connector) error {
+		if n < 1 {
+			return fmt.Errorf("go-ase: maximum number of buffered rows must be positive, got %d", n)
+		}
+
+		c.MaxBufferedRows = n
+		return nil
+	}
+}
+
+// maxBufferedRows returns the maximum number of rows of a response read
+// into memory by bufferResponse.
+func (c *Connector) maxBufferedRows() int {
+	if c.MaxBufferedRows > 0 {
+		return c.MaxBufferedRows
+	}
+	return DefaultMaxBufferedRows
+}
+
+// bufferResponse reads the remaining response of the exchange that
+// returned rows from the channel to free the channel for other
+// exchanges.
+//
+// Multiplexing the exchanges through additional logical channels is not
+// possible as tds.Conn.NewChannel expects a *tds.HeaderOnlyPackage as
+// acknowledgement of the channel setup, while tds.Channel passes
+// tds.HeaderOnlyPackage values.
+//
+// If the remaining response has more than Conn.maxBufferedRows rows
+// ErrResultSetOpen is returned. The packages read so far stay in the
+// buffer and the rows read the rest of the response from the channel.
+func (rows *Rows) bufferResponse(ctx context.Context) error {
+	bufferedRows := 0
+	for _, pkg := range rows.buffer {
+		if _, ok := pkg.(*tds.RowPackage); ok {
+			bufferedRows++
+		}
+	}
+
+	for {
+		if bufferedRows >= rows.Conn.maxBufferedRows {
+			return ErrResultSetOpen
+		}
+
+		pkg, err := rows.Conn.channel.NextPackage(ctx, true)
+		if err != nil {
+			return fmt.Errorf("go-ase: error buffering result set: %w", err)
+		}
+
+		switch typed := pkg.(type) {
+		case *tds.DonePackage:
+			rows.Conn.trackTransaction(typed)
+		case *tds.RowPackage:
+			bufferedRows++
+		}
+
+		rows.buffer = append(rows.buffer, pkg)
+		if isDoneFinal(pkg) {
+			break
+		}
+	}
+
+	rows.buffered = true
+	rows.Conn.openRows = nil
+	return nil
+}
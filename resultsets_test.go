@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+)
+
+func TestMultipleResultSets(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect(withNoQueryCursor, WithMultipleResultSets())
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "select 1", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+
+	recorder := messageRecorder{}
+	ctx := WithMessageHandler(context.Background(), recorder.handle)
+	other, err := conn.QueryContext(ctx, "select 1; print 'a'", nil)
+	if err != nil {
+		t.Fatalf("error executing query while rows are open: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "print 'b'", nil); err != nil {
+		t.Fatalf("error executing statement while rows are open: %v", err)
+	}
+
+	for _, r := range []driver.Rows{rows, other} {
+		values := make([]driver.Value, 1)
+		if err := r.Next(values); err != nil {
+			t.Fatalf("error reading row: %v", err)
+		}
+
+		if values[0] != int32(1) {
+			t.Errorf("expected 1, got %v", values[0])
+		}
+
+		if err := r.Next(values); !errors.Is(err, io.EOF) {
+			t.Errorf("expected io.EOF, got %v", err)
+		}
+
+		if err := r.Close(); err != nil {
+			t.Errorf("error closing rows: %v", err)
+		}
+	}
+
+	// The messages of buffered rows are passed when the rows are read.
+	if !reflect.DeepEqual(recorder, messageRecorder{"1: a"}) {
+		t.Errorf("unexpected messages: %v", recorder)
+	}
+
+	expected := []string{"select 1", "select 1; print 'a'", "print 'b'"}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected commands %v, got %v", expected, commands)
+	}
+}
+
+func TestResultSetOpenRead(t *testing.T) {
+	server := newPrintServer(t)
+	conn := server.connect(withNoQueryCursor)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "select 1", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+
+	values := make([]driver.Value, 1)
+	for {
+		if err := rows.Next(values); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("error reading row: %v", err)
+		}
+	}
+
+	// Rows whose response has been read completely do not occupy the
+	// connection.
+	if _, err := conn.ExecContext(context.Background(), "print 'a'", nil); err != nil {
+		t.Errorf("error executing statement: %v", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Errorf("error closing rows: %v", err)
+	}
+}
+
+func TestMultipleResultSetsLimit(t *testing.T) {
+	cases := map[string]struct {
+		limit int
+		opts  []ConnectorOption
+	}{
+		"default": {limit: DefaultMaxBufferedRows},
+		"custom":  {limit: 5, opts: []ConnectorOption{WithMaxBufferedRows(5)}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			testMultipleResultSetsLimit(t, c.limit, c.opts...)
+		})
+	}
+}
+
+func testMultipleResultSetsLimit(t *testing.T, limit int, opts ...ConnectorOption) {
+	server := newTestServer(t)
+	result := asetest.Result{Columns: []asetest.Column{{Name: "value"}}}
+	for i := 0; i < limit+2; i++ {
+		result.Rows = append(result.Rows, []interface{}{int32(i)})
+	}
+	server.Handle("select value from t", result)
+	server.Handle("print 'a'")
+
+	conn := server.connect(append(opts, withNoQueryCursor, WithMultipleResultSets())...)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(context.Background(), "select value from t", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+
+	values := make([]driver.Value, 1)
+	if err := rows.Next(values); err != nil {
+		t.Fatalf("error reading row: %v", err)
+	}
+
+	// The remaining response exceeds the limit, repeated attempts do
+	// not buffer more rows.
+	for i := 0; i < 2; i++ {
+		if _, err := conn.ExecContext(context.Background(), "print 'a'", nil); !errors.Is(err, ErrResultSetOpen) {
+			t.Fatalf("expected ErrResultSetOpen, got %v", err)
+		}
+	}
+
+	// The rows read the buffered rows and the rest of the response
+	// from the channel.
+	for i := 1; ; i++ {
+		if err := rows.Next(values); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("error reading row: %v", err)
+			}
+			if i != limit+2 {
+				t.Errorf("expected %d rows, read %d", limit+2, i)
+			}
+			break
+		}
+
+		if values[0] != int32(i) {
+			t.Fatalf("expected %d, got %v", i, values[0])
+		}
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "print 'a'", nil); err != nil {
+		t.Errorf("error executing statement: %v", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Errorf("error closing rows: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Backoff returns the delay before the passed retry, starting at 1.
+type Backoff func(retry int) time.Duration
+
+// ExponentialBackoff returns a Backoff doubling the delay with every
+// retry, starting at initial and limited to max.
+func ExponentialBackoff(initial, max time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		delay := initial
+		for i := 1; i < retry && delay < max; i++ {
+			delay *= 2
+		}
+
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// TxAttempt reports an attempt of RunInTx to the OnAttempt callback.
+type TxAttempt struct {
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt int
+	// Err is the error of the attempt, nil if the transaction was
+	// committed.
+	Err error
+	// Retry is true if the transaction is retried after Delay.
+	Retry bool
+	Delay time.Duration
+	// Duration is the time taken by the attempt.
+	Duration time.Duration
+}
+
+// RetryOptions configure RunInTx.
+type RetryOptions struct {
+	// TxOptions are passed to sql.DB.BeginTx.
+	TxOptions *sql.TxOptions
+
+	// MaxAttempts limits the number of attempts including the first.
+	// Defaults to 3.
+	MaxAttempts int
+
+	// Backoff returns the delay before a retry. Defaults to an
+	// exponential backoff from 100ms to 5s.
+	Backoff Backoff
+
+	// OnAttempt is called after every attempt if set.
+	OnAttempt func(ctx context.Context, attempt TxAttempt)
+}
+
+// Defaults of RetryOptions.
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBackoffMin  = 100 * time.Millisecond
+	defaultRetryBackoffMax  = 5 * time.Second
+)
+
+// RunInTx executes fn in a transaction on db and commits the
+// transaction if fn returns nil, otherwise the transaction is rolled
+// back.
+//
+// The transaction is retried if it failed with a retryable error as
+// reported by IsRetryable. Errors returned by the commit are only
+// retried if the server rolled back the transaction, since the outcome
+// of a commit interrupted by a connection reset is unknown.
+//
+// fn may be called multiple times and must not have side effects
+// outside of the transaction. opts may be nil to use the defaults.
+func RunInTx(ctx context.Context, db *sql.DB, opts *RetryOptions, fn func(*sql.Tx) error) error {
+	if opts == nil {
+		opts = &RetryOptions{}
+	}
+
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+
+	backoff := opts.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(defaultRetryBackoffMin, defaultRetryBackoffMax)
+	}
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		retryable, err := runTx(ctx, db, opts.TxOptions, fn)
+
+		report := TxAttempt{
+			Attempt:  attempt,
+			Err:      err,
+			Retry:    err != nil && retryable && attempt < maxAttempts && ctx.Err() == nil,
+			Duration: time.Since(start),
+		}
+		if report.Retry {
+			report.Delay = backoff(attempt)
+		}
+
+		if opts.OnAttempt != nil {
+			opts.OnAttempt(ctx, report)
+		}
+
+		if !report.Retry {
+			if err != nil && retryable {
+				return fmt.Errorf("go-ase: transaction failed after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+
+		timer := time.NewTimer(report.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("go-ase: transaction failed after %d attempts: %w", attempt, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// runTx executes a single attempt of RunInTx and reports whether the
+// returned error permits a retry.
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(*sql.Tx) error) (bool, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return IsRetryable(err), err
+	}
+
+	if err := fn(tx); err != nil {
+		// The server may already have rolled back the transaction,
+		// e.g. after a deadlock.
+		_ = tx.Rollback()
+		return IsRetryable(err), err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return isTxAborted(err), err
+	}
+
+	return false, nil
+}
+
+// IsRetryable reports whether err is a transient error after which
+// a transaction can be retried: the transaction was chosen as deadlock
+// victim, a lock could not be acquired in time or the connection was
+// reset.
+func IsRetryable(err error) bool {
+	return isTxAborted(err) || isConnReset(err)
+}
+
+// isTxAborted reports whether err was caused by the server aborting the
+// transaction due to a deadlock or lock timeout.
+func isTxAborted(err error) bool {
+	return hasMsgNumber(err, msgDeadlock) ||
+		hasMsgNumber(err, msgLockTimeout) ||
+		hasMsgNumber(err, msgLockTimeoutSession)
+}
+
+// isConnReset reports whether err was caused by the loss of the
+// connection.
+func isConnReset(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, tds.ErrChannelClosed) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
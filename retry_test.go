@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// deadlockServer is a server reporting the first failures inserts as
+// deadlock victims.
+type deadlockServer struct {
+	*testServer
+	failures int
+}
+
+func newDeadlockServer(t *testing.T, failures int) *deadlockServer {
+	s := &deadlockServer{testServer: newTestServer(t), failures: failures}
+	s.HandleFunc("", s.handle)
+	return s
+}
+
+func (s *deadlockServer) handle(req asetest.Request) []asetest.Result {
+	if !strings.HasPrefix(req.Query, "insert") || s.failures == 0 {
+		return nil
+	}
+	s.failures--
+
+	return []asetest.Result{{Error: &asetest.Message{
+		Number:   msgDeadlock,
+		Severity: 13,
+		Text:     "Your server command was deadlocked with another process and has been chosen as deadlock victim.",
+	}}}
+}
+
+func (s *deadlockServer) db() *sql.DB {
+	db := sql.OpenDB(s.connector())
+	s.t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func insert(tx *sql.Tx) error {
+	_, err := tx.Exec("insert into t values (1)")
+	return err
+}
+
+func TestRunInTxDeadlock(t *testing.T) {
+	server := newDeadlockServer(t, 2)
+
+	var attempts []TxAttempt
+	opts := &RetryOptions{
+		Backoff: func(int) time.Duration { return time.Millisecond },
+		OnAttempt: func(_ context.Context, attempt TxAttempt) {
+			attempts = append(attempts, attempt)
+		},
+	}
+
+	if err := RunInTx(context.Background(), server.db(), opts, insert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(attempts))
+	}
+
+	for i, attempt := range attempts {
+		if attempt.Attempt != i+1 {
+			t.Errorf("expected attempt %d, got %d", i+1, attempt.Attempt)
+		}
+
+		retry := i < 2
+		if attempt.Retry != retry || (attempt.Err != nil) != retry {
+			t.Errorf("attempt %d: unexpected retry %t with error %v", attempt.Attempt, attempt.Retry, attempt.Err)
+		}
+
+		if retry && attempt.Delay != time.Millisecond {
+			t.Errorf("attempt %d: unexpected delay %s", attempt.Attempt, attempt.Delay)
+		}
+	}
+
+	expected := []string{
+		"begin transaction ", "insert into t values (1)", "rollback ",
+		"begin transaction ", "insert into t values (1)", "rollback ",
+		"begin transaction ", "insert into t values (1)", "commit ",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestRunInTxMaxAttempts(t *testing.T) {
+	server := newDeadlockServer(t, 3)
+
+	opts := &RetryOptions{
+		MaxAttempts: 2,
+		Backoff:     func(int) time.Duration { return 0 },
+	}
+
+	err := RunInTx(context.Background(), server.db(), opts, insert)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if msgNumber, ok := MessageNumber(err); !ok || msgNumber != msgDeadlock {
+		t.Errorf("expected message number %d, got %d", msgDeadlock, msgNumber)
+	}
+
+	if failures := server.failures; failures != 1 {
+		t.Errorf("expected one remaining failure, got %d", failures)
+	}
+}
+
+func TestRunInTxNotRetryable(t *testing.T) {
+	server := newDeadlockServer(t, 0)
+
+	errFn := errors.New("application error")
+	attempts := 0
+	opts := &RetryOptions{
+		OnAttempt: func(context.Context, TxAttempt) { attempts++ },
+	}
+
+	err := RunInTx(context.Background(), server.db(), opts, func(*sql.Tx) error {
+		return errFn
+	})
+	if err != errFn {
+		t.Errorf("expected application error, got: %v", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func eedError(msgNumbers ...uint32) error {
+	err := &tds.EEDError{WrappedError: errors.New("query failed with errors")}
+	for _, msgNumber := range msgNumbers {
+		err.Add(&tds.EEDPackage{MsgNumber: msgNumber, Class: 16})
+	}
+	return fmt.Errorf("go-ase: error executing statement: %w", err)
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := map[string]struct {
+		err       error
+		retryable bool
+	}{
+		"deadlock":           {eedError(msgDeadlock), true},
+		"lock timeout":       {eedError(msgLockTimeout), true},
+		"session timeout":    {eedError(msgLockTimeoutSession), true},
+		"duplicate key":      {eedError(2601), false},
+		"bad connection":     {fmt.Errorf("go-ase: %w", driver.ErrBadConn), true},
+		"closed channel":     {fmt.Errorf("go-ase: %w", tds.ErrChannelClosed), true},
+		"unexpected EOF":     {fmt.Errorf("go-ase: %w", io.ErrUnexpectedEOF), true},
+		"application error":  {errors.New("application error"), false},
+		"nil":                {nil, false},
+		"deadlock in second": {eedError(3621, msgDeadlock), true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if retryable := IsRetryable(tc.err); retryable != tc.retryable {
+				t.Errorf("expected %t, got %t", tc.retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestMessageNumber(t *testing.T) {
+	err := &tds.EEDError{}
+	err.Add(&tds.EEDPackage{MsgNumber: 5701, Class: 10})
+	err.Add(&tds.EEDPackage{MsgNumber: msgDeadlock, Class: 13})
+
+	if msgNumber, ok := MessageNumber(fmt.Errorf("wrapped: %w", err)); !ok || msgNumber != msgDeadlock {
+		t.Errorf("expected message number %d, got %d", msgDeadlock, msgNumber)
+	}
+
+	if _, ok := MessageNumber(errors.New("no messages")); ok {
+		t.Errorf("expected no message number")
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+
+	for i, delay := range expected {
+		if received := backoff(i + 1); received != delay {
+			t.Errorf("retry %d: expected %s, got %s", i+1, delay, received)
+		}
+	}
+}
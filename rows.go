@@ -172,12 +172,21 @@ type Rows struct {
 	RowFmt *tds.RowFmtPackage
 
 	hasNextResultSet bool
+
+	// messages passes the messages of the result sets to the
+	// MessageHandler of the exchange that returned the rows.
+	messages *messages
+	// buffered is true if the remaining response has been read into
+	// buffer to free the channel for other commands.
+	buffered bool
+	buffer   []tds.Package
 }
 
 func (conn *Conn) NewRows() *Rows {
 	rows := new(Rows)
 
 	rows.Conn = conn
+	rows.messages = conn.messages
 	rows.rowFmt = func() *tds.RowFmtPackage { return rows.RowFmt }
 
 	return rows
@@ -189,12 +198,20 @@ func (rows *Rows) Close() error {
 		return nil
 	}
 
+	if err := rows.Conn.acquireRows(rows); err != nil {
+		return err
+	}
+	defer rows.Conn.release()
+
 	defer func() {
 		rows.closed = true
+		if rows.Conn.openRows == rows {
+			rows.Conn.openRows = nil
+		}
 	}()
 
 	for {
-		if err := rows.NextResultSet(); err != nil {
+		if err := rows.nextResultSet(); err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
@@ -211,40 +228,29 @@ func (rows *Rows) Next(dst []driver.Value) error {
 		return io.EOF
 	}
 
-	_, err := rows.Conn.Channel.NextPackageUntil(context.Background(), true,
-		func(pkg tds.Package) (bool, error) {
-			switch typed := pkg.(type) {
-			case *tds.RowPackage:
-				if len(dst) != len(typed.DataFields) {
-					return true, fmt.Errorf("go-ase: received invalid number of destinations, expecting %d destinations, got %d", len(typed.DataFields), len(dst))
-				}
-				for i := range typed.DataFields {
-					dst[i] = typed.DataFields[i].Value()
-				}
-				return true, nil
-			case *tds.RowFmtPackage:
-				rows.RowFmt = typed
-				rows.hasNextResultSet = true
-				return false, io.EOF
-			case *tds.OrderByPackage, *tds.OrderBy2Package:
-				return false, nil
-			case *tds.DonePackage:
-				ok, err := handleDonePackage(typed)
-				if err != nil {
-					return true, fmt.Errorf("go-ase: %w", err)
-				}
-
-				return ok, nil
-			case *tds.ReturnStatusPackage:
-				if typed.ReturnValue != 0 {
-					return true, fmt.Errorf("go-ase: query failed with return status %d", typed.ReturnValue)
-				}
-				return false, nil
-			default:
-				return true, fmt.Errorf("unhandled package type %T: %v", pkg, pkg)
+	if err := rows.Conn.acquireRows(rows); err != nil {
+		return err
+	}
+	defer rows.Conn.release()
+
+	_, err := rows.Conn.readStream(context.Background(), true, resultStream{
+		operation: "rows next",
+		done:      doneResults,
+		row: func(pkg *tds.RowPackage) (bool, error) {
+			if len(dst) != len(pkg.DataFields) {
+				return true, fmt.Errorf("go-ase: received invalid number of destinations, expecting %d destinations, got %d", len(pkg.DataFields), len(dst))
+			}
+			for i := range pkg.DataFields {
+				dst[i] = pkg.DataFields[i].Value()
 			}
+			return true, nil
+		},
+		rowFmt: func(pkg *tds.RowFmtPackage) (bool, error) {
+			rows.RowFmt = pkg
+			rows.hasNextResultSet = true
+			return false, io.EOF
 		},
-	)
+	})
 
 	if err != nil {
 		// database/sql expects only an io.EOF - it doesn't check with
@@ -273,27 +279,31 @@ func (rows *Rows) NextResultSet() error {
 		return io.EOF
 	}
 
+	if err := rows.Conn.acquireRows(rows); err != nil {
+		return err
+	}
+	defer rows.Conn.release()
+
+	return rows.nextResultSet()
+}
+
+// nextResultSet advances rows to the next result set, the connection
+// must be acquired by the caller.
+func (rows *Rows) nextResultSet() error {
 	// discard all RowPackage until either end of communication or next
 	// RowFmtPackage
-	_, err := rows.Conn.Channel.NextPackageUntil(context.Background(), false,
-		func(pkg tds.Package) (bool, error) {
-			switch typed := pkg.(type) {
-			case *tds.RowFmtPackage:
-				rows.RowFmt = typed
-				rows.hasNextResultSet = true
-				return false, nil
-			case *tds.RowPackage, *tds.OrderByPackage, *tds.OrderBy2Package:
-				return true, nil
-			case *tds.DonePackage:
-				if typed.Status&tds.TDS_DONE_MORE == tds.TDS_DONE_MORE {
-					return false, nil
-				}
-				return true, fmt.Errorf("go-ase: no next result set: %w", io.EOF)
-			default:
-				return false, fmt.Errorf("unhandled package type %T: %v", pkg, pkg)
-			}
+	_, err := rows.Conn.readStream(context.Background(), false, resultStream{
+		operation: "rows next result set",
+		done:      doneResultSets,
+		row: func(*tds.RowPackage) (bool, error) {
+			return true, nil
+		},
+		rowFmt: func(pkg *tds.RowFmtPackage) (bool, error) {
+			rows.RowFmt = pkg
+			rows.hasNextResultSet = true
+			return false, nil
 		},
-	)
+	})
 
 	if err != nil {
 		if errors.Is(err, tds.ErrNoPackageReady) || errors.Is(err, io.EOF) {
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// The scenarios in this file are run against ASE by the integration
+// tests and against a scripted server by the tests in asetest_test.go.
+
+// interface to match both Rows and CursorRows.
+type sqlRows interface {
+	Next([]driver.Value) error
+}
+
+// fetchRows expects the passed rows to return {int, string} and logs
+// all rows.
+//
+// rows is not closed automatically.
+func fetchRows(t *testing.T, rows sqlRows) {
+	values := []driver.Value{0, ""}
+	for {
+		if err := rows.Next(values); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Errorf("error reading row: %v", err)
+			return
+		}
+
+		t.Logf("| %d | %s |", values[0], values[1])
+	}
+}
+
+func cursorFetch(t *testing.T, cursor *Cursor) {
+	rows, err := cursor.Fetch(context.Background())
+	if err != nil {
+		t.Errorf("error fetching result set: %v", err)
+		return
+	}
+
+	fetchRows(t, rows)
+
+	if err := rows.Close(); err != nil {
+		t.Errorf("error closing rows: %v", err)
+	}
+}
+
+func singleCursor(t *testing.T, conn *Conn, tableName string) {
+	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
+	if err != nil {
+		t.Errorf("error creating cursor: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor)
+}
+
+func singleCursorWithArgs(t *testing.T, conn *Conn, tableName string) {
+	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
+	if err != nil {
+		t.Errorf("error creating cursor: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor)
+}
+
+func twoCursors(t *testing.T, conn *Conn, tableName string) {
+	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
+	if err != nil {
+		t.Errorf("error creating cursor: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor)
+
+	cursor2, err := conn.NewCursor(context.Background(), "select * from "+tableName)
+	if err != nil {
+		t.Errorf("error creating cursor2: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor2.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor2: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor2)
+}
+
+func twoCursorsOneWithArgs(t *testing.T, conn *Conn, tableName string) {
+	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
+	if err != nil {
+		t.Errorf("error creating cursor: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor)
+
+	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
+	if err != nil {
+		t.Errorf("error creating cursorWithArgs: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursorWithArgs.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursorWithArgs: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursorWithArgs)
+}
+
+func twoCursorsOneWithArgs2(t *testing.T, conn *Conn, tableName string) {
+	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
+	if err != nil {
+		t.Errorf("error creating cursorWithArgs: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursorWithArgs.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursorWithArgs: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursorWithArgs)
+
+	cursor, err := conn.NewCursor(context.Background(), "select * from "+tableName)
+	if err != nil {
+		t.Errorf("error creating cursor: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursor.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursor: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursor)
+}
+
+func twoCursorsWithArgs(t *testing.T, conn *Conn, tableName string) {
+	cursorWithArgs, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
+	if err != nil {
+		t.Errorf("error creating cursorWithArgs: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursorWithArgs.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursorWithArgs: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursorWithArgs)
+
+	cursorWithArgs2, err := conn.NewCursor(context.Background(), "select * from "+tableName+" where b like (?)", "two")
+	if err != nil {
+		t.Errorf("error creating cursorWithArgs2: %v", err)
+		return
+	}
+	defer func() {
+		if err := cursorWithArgs2.Close(context.Background()); err != nil {
+			t.Errorf("error closing cursorWithArgs2: %v", err)
+		}
+	}()
+
+	cursorFetch(t, cursorWithArgs2)
+}
+
+// directExec returns a scenario executing query with DirectExec. The
+// query is formatted with the name of the table.
+func directExec(query string, args ...interface{}) func(*testing.T, *Conn, string) {
+	return func(t *testing.T, conn *Conn, tableName string) {
+		timeout, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		rows, result, err := conn.DirectExec(timeout, fmt.Sprintf(query, tableName), args...)
+		if err != nil {
+			t.Errorf("received error on DirectExec: %v", err)
+			return
+		}
+
+		if rows == nil {
+			t.Errorf("received nil rows")
+		} else {
+			fetchRows(t, rows)
+			if err := rows.Close(); err != nil {
+				t.Errorf("error closing rows: %v", err)
+			}
+		}
+
+		if result == nil {
+			t.Errorf("received nil result")
+		}
+	}
+}
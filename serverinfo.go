@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// ServerInfo describes the server a connection is logged in to.
+type ServerInfo struct {
+	// ServerName is the name of the server as reported by @@servername.
+	ServerName string
+	// ProductName is the name of the server program as reported in the
+	// login acknowledgement.
+	ProductName string
+	// Version is the version of the server program as reported in the
+	// login acknowledgement.
+	Version ServerVersion
+	// VersionString is the full version string as reported by
+	// @@version.
+	VersionString string
+	// TDSVersion is the version of the TDS protocol acknowledged by the
+	// server.
+	TDSVersion string
+	// PacketSize is the negotiated packet size in bytes.
+	PacketSize int
+	// Charset is the character set of the connection.
+	Charset string
+	// Capabilities are the capabilities acknowledged by the server
+	// during login.
+	Capabilities *tds.CapabilityPackage
+}
+
+// ServerVersion is the version of a server product, e.g. 16.0 SP03 PL02
+// is represented as {16, 0, 3, 2}.
+type ServerVersion struct {
+	Major, Minor, ServicePack, PatchLevel int
+}
+
+// String returns the version in the format used by ASE, e.g.
+// "16.0 SP03 PL02".
+func (v ServerVersion) String() string {
+	s := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	if v.ServicePack > 0 || v.PatchLevel > 0 {
+		s += fmt.Sprintf(" SP%02d", v.ServicePack)
+	}
+	if v.PatchLevel > 0 {
+		s += fmt.Sprintf(" PL%02d", v.PatchLevel)
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or higher than
+// other.
+func (v ServerVersion) Compare(other ServerVersion) int {
+	for _, diff := range []int{
+		v.Major - other.Major,
+		v.Minor - other.Minor,
+		v.ServicePack - other.ServicePack,
+		v.PatchLevel - other.PatchLevel,
+	} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is equal to or higher than other, e.g. to
+// gate features by server version.
+func (v ServerVersion) AtLeast(other ServerVersion) bool {
+	return v.Compare(other) >= 0
+}
+
+// newServerInfo returns the information about the server in the login
+// acknowledgement.
+func newServerInfo(loginAck *tds.LoginAckPackage, caps *tds.CapabilityPackage) *ServerInfo {
+	info := &ServerInfo{Capabilities: caps}
+	if loginAck == nil {
+		return info
+	}
+
+	info.ProductName = strings.TrimSpace(loginAck.ProgramName)
+	if loginAck.ProgramVersion != nil {
+		version := loginAck.ProgramVersion.Bytes()
+		info.Version = ServerVersion{int(version[0]), int(version[1]), int(version[2]), int(version[3])}
+	}
+	if loginAck.Version != nil {
+		info.TDSVersion = loginAck.Version.String()
+	}
+
+	return info
+}
+
+// ServerInfo returns information about the server the connection is
+// logged in to.
+//
+// The product name, version, TDS version and capabilities are those
+// acknowledged by the server during login. The packet size and
+// character set are the current values of the connection as reported
+// through TDS_ENVCHANGE.
+//
+// The login does not carry the server name and the full version
+// string, which are retrieved from the server on the first call and
+// cached for the lifetime of the connection.
+func (c *Conn) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	c.serverInfoLock.Lock()
+	defer c.serverInfoLock.Unlock()
+
+	if !c.serverInfoQueried {
+		if err := c.queryServerInfo(ctx); err != nil {
+			return nil, err
+		}
+		c.serverInfoQueried = true
+	}
+
+	info := *c.serverInfo
+	info.PacketSize = c.Conn.PacketSize()
+	if charset := c.env.Session().Charset; charset != "" {
+		info.Charset = charset
+	}
+	return &info, nil
+}
+
+// queryServerInfo retrieves the server name and version string, and the
+// character set if the server did not report it during login.
+func (c *Conn) queryServerInfo(ctx context.Context) error {
+	query := "select @@servername, @@version"
+	if c.env.Session().Charset == "" {
+		query += ", @@client_csname"
+	}
+
+	rows, _, err := c.GenericExec(ctx, query, nil)
+	if err != nil {
+		return fmt.Errorf("go-ase: error querying server information: %w", err)
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, len(rows.Columns()))
+	if err := rows.Next(values); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("go-ase: server did not report server information")
+		}
+		return fmt.Errorf("go-ase: error reading server information: %w", err)
+	}
+
+	// @@servername is null if the server name is not configured.
+	if serverName, ok := values[0].(string); ok {
+		c.serverInfo.ServerName = strings.TrimSpace(serverName)
+	}
+
+	versionString, ok := values[1].(string)
+	if !ok {
+		return fmt.Errorf("go-ase: unexpected version string of type %T", values[1])
+	}
+	c.serverInfo.VersionString = strings.TrimSpace(versionString)
+
+	if len(values) > 2 {
+		if charset, ok := values[2].(string); ok {
+			c.serverInfo.Charset = strings.TrimSpace(charset)
+		}
+	}
+
+	return nil
+}
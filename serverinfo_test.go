@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+func TestNewServerInfo(t *testing.T) {
+	tdsVersion, _ := tds.NewVersion([]byte{5, 0, 0, 0})
+	programVersion, _ := tds.NewVersion([]byte{16, 0, 3, 2})
+
+	info := newServerInfo(&tds.LoginAckPackage{
+		Status:         tds.TDS_LOG_SUCCEED,
+		Version:        tdsVersion,
+		ProgramName:    "sql server",
+		ProgramVersion: programVersion,
+	}, nil)
+
+	expected := &ServerInfo{
+		ProductName: "sql server",
+		Version:     ServerVersion{16, 0, 3, 2},
+		TDSVersion:  "5.0.0.0",
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info)
+	}
+
+	if info := newServerInfo(nil, nil); !reflect.DeepEqual(info, &ServerInfo{}) {
+		t.Errorf("expected empty server info without login acknowledgement, got %+v", info)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	version := ServerVersion{16, 0, 3, 2}
+
+	if s := version.String(); s != "16.0 SP03 PL02" {
+		t.Errorf("unexpected string: %s", s)
+	}
+
+	if s := (ServerVersion{15, 7, 0, 0}).String(); s != "15.7" {
+		t.Errorf("unexpected string: %s", s)
+	}
+
+	if !version.AtLeast(ServerVersion{16, 0, 3, 0}) {
+		t.Errorf("expected %s to be at least 16.0 SP03", version)
+	}
+
+	if version.AtLeast(ServerVersion{16, 0, 4, 0}) {
+		t.Errorf("expected %s to be lower than 16.0 SP04", version)
+	}
+
+	if version.Compare(version) != 0 {
+		t.Errorf("expected version to equal itself")
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	server := newTestServer(t)
+	server.HandleFunc("", func(asetest.Request) []asetest.Result {
+		return []asetest.Result{{
+			Columns: []asetest.Column{{Name: "name"}, {Name: "version"}, {Name: "charset"}},
+			Rows:    [][]interface{}{{"FAKE", "Adaptive Server Enterprise/16.0 SP04 PL03/EBF 30399 SMP/P/x86_64", "utf8"}},
+		}}
+	})
+	conn := server.connect()
+
+	// The product and version are acknowledged during login.
+	if conn.serverInfo.ProductName != "asetest" || conn.serverInfo.Version != (ServerVersion{16, 0, 4, 3}) {
+		t.Errorf("unexpected server info after login: %+v", conn.serverInfo)
+	}
+
+	info, err := conn.ServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("error retrieving server info: %v", err)
+	}
+
+	if info.ServerName != "FAKE" || info.ProductName != "asetest" {
+		t.Errorf("unexpected server: %q %q", info.ServerName, info.ProductName)
+	}
+
+	if info.Version != (ServerVersion{16, 0, 4, 3}) || info.VersionString != "Adaptive Server Enterprise/16.0 SP04 PL03/EBF 30399 SMP/P/x86_64" {
+		t.Errorf("unexpected version: %s %q", info.Version, info.VersionString)
+	}
+
+	if info.Charset != "utf8" || info.TDSVersion != "5.0.0.0" || info.PacketSize != conn.Conn.PacketSize() {
+		t.Errorf("unexpected connection properties: %q %q %d", info.Charset, info.TDSVersion, info.PacketSize)
+	}
+
+	if info.Capabilities == nil || !info.Capabilities.HasRequestCapability(tds.TDS_REQ_LANG) {
+		t.Errorf("expected capabilities acknowledged by server")
+	}
+
+	if _, err := conn.ServerInfo(context.Background()); err != nil {
+		t.Fatalf("error retrieving server info: %v", err)
+	}
+
+	// Only the values missing from the login are queried, once.
+	expected := []string{"select @@servername, @@version, @@client_csname"}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected commands %q, got %q", expected, commands)
+	}
+}
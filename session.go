@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Interface satisfaction checks
+var (
+	_ driver.SessionResetter = (*Conn)(nil)
+)
+
+// WithInitStatements adds statements to the connector that are executed
+// on every new connection.
+func WithInitStatements(statements ...string) ConnectorOption {
+	return func(c *Connector) error {
+		c.InitStatements = append(c.InitStatements, statements...)
+		return nil
+	}
+}
+
+// initStatements returns the statements to execute after a connection
+// has been established.
+func (c *Connector) initStatements() []string {
+	statements := make([]string, 0, len(c.InitStatements)+1)
+	if c.Info.InitSQL != "" {
+		statements = append(statements, c.Info.InitSQL)
+	}
+	return append(statements, c.InitStatements...)
+}
+
+// applyInitStatements executes the init statements of the connection.
+func (c *Conn) applyInitStatements(ctx context.Context) error {
+	for _, statement := range c.initStatements {
+		if _, err := c.ExecContext(ctx, statement, nil); err != nil {
+			return fmt.Errorf("go-ase: error executing init statement %q: %w", statement, err)
+		}
+	}
+
+	return nil
+}
+
+// ResetSession implements the driver.SessionResetter interface.
+//
+// ResetSession rolls back a transaction that was opened and not ended,
+// switches the connection back from chained mode if it was enabled
+// with SetOption, switches back to the database the connection was
+// established with if it was changed, e.g. by a `use` statement, and
+// re-applies the init statements of the connection before the
+// connection is reused by database/sql.
+//
+// A transaction opened implicitly in chained mode outside of
+// a Transaction is committed, as the statements of the transaction were
+// executed outside of a Transaction.
+//
+// If any of these fails the connection is reported as driver.ErrBadConn
+// to be discarded by database/sql.
+func (c *Conn) ResetSession(ctx context.Context) error {
+	// Neither the database nor the transaction mode can be changed
+	// inside a transaction.
+	switch {
+	case c.tx != nil:
+		if err := c.tx.Rollback(); err != nil {
+			return fmt.Errorf("%w: go-ase: error rolling back abandoned transaction: %v", driver.ErrBadConn, err)
+		}
+	case c.InTransaction() && c.Chained():
+		if _, err := c.ExecContext(ctx, "commit transaction", nil); err != nil {
+			return fmt.Errorf("%w: go-ase: error committing implicit transaction: %v", driver.ErrBadConn, err)
+		}
+	case c.InTransaction():
+		if _, err := c.ExecContext(ctx, "rollback transaction", nil); err != nil {
+			return fmt.Errorf("%w: go-ase: error rolling back open transaction: %v", driver.ErrBadConn, err)
+		}
+	}
+
+	if c.Chained() {
+		if err := c.SetOption(ctx, OptionChained, false); err != nil {
+			return fmt.Errorf("%w: go-ase: error disabling chained transaction mode: %v", driver.ErrBadConn, err)
+		}
+	}
+
+	if database := c.Session().Database; c.database != "" && database != c.database {
+		if _, err := c.ExecContext(ctx, "use "+c.database, nil); err != nil {
+			return fmt.Errorf("%w: go-ase: error switching back from database %s to %s: %v", driver.ErrBadConn, database, c.database, err)
+		}
+	}
+
+	if err := c.applyInitStatements(ctx); err != nil {
+		return fmt.Errorf("%w: %v", driver.ErrBadConn, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// sessionServer is a server reporting database changes by use
+// statements through TDS_ENVCHANGE.
+type sessionServer struct {
+	*testServer
+	database string
+}
+
+func newSessionServer(t *testing.T) *sessionServer {
+	s := &sessionServer{testServer: newTestServer(t), database: "master"}
+	s.HandleFunc("", s.handle)
+	return s
+}
+
+func (s *sessionServer) handle(req asetest.Request) []asetest.Result {
+	if !strings.HasPrefix(req.Query, "use ") {
+		return nil
+	}
+
+	database := strings.TrimPrefix(req.Query, "use ")
+	change := asetest.EnvChange{Type: tds.TDS_ENV_DB, Old: s.database, New: database}
+	s.database = database
+
+	return []asetest.Result{{EnvChanges: []asetest.EnvChange{
+		change,
+		{Type: tds.TDS_ENV_LANG, New: "us_english"},
+		{Type: tds.TDS_ENV_CHARSET, New: "utf8"},
+	}}}
+}
+
+func TestSession(t *testing.T) {
+	server := newSessionServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.Database = "db1"
+		return nil
+	})
+
+	expected := Session{
+		Database:   "db1",
+		Language:   "us_english",
+		Charset:    "utf8",
+		PacketSize: 0,
+	}
+	if session := conn.Session(); !reflect.DeepEqual(session, expected) {
+		t.Errorf("unexpected session:\nexpected: %#v\nreceived: %#v", expected, session)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "use db2", nil); err != nil {
+		t.Fatalf("error switching database: %v", err)
+	}
+
+	if database := conn.Session().Database; database != "db2" {
+		t.Errorf("expected database db2, got %q", database)
+	}
+}
+
+func TestResetSessionDatabase(t *testing.T) {
+	server := newSessionServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.Database = "db1"
+		return nil
+	})
+
+	if err := conn.ResetSession(context.Background()); err != nil {
+		t.Fatalf("error resetting session: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "use db2", nil); err != nil {
+		t.Fatalf("error switching database: %v", err)
+	}
+
+	if err := conn.ResetSession(context.Background()); err != nil {
+		t.Fatalf("error resetting session: %v", err)
+	}
+
+	if database := conn.Session().Database; database != "db1" {
+		t.Errorf("expected database db1 after reset, got %q", database)
+	}
+
+	expected := []string{"use db1", "use db2", "use db1"}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
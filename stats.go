@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"encoding/binary"
+	"expvar"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// Operation is a type of database call whose latency is recorded in
+// Stats.
+type Operation string
+
+// Operations with recorded latencies.
+const (
+	OperationQuery       Operation = "query"
+	OperationPrepare     Operation = "prepare"
+	OperationCursorOpen  Operation = "cursor_open"
+	OperationCursorFetch Operation = "cursor_fetch"
+	OperationCursorClose Operation = "cursor_close"
+	OperationTxBegin     Operation = "tx_begin"
+	OperationTxCommit    Operation = "tx_commit"
+	OperationTxRollback  Operation = "tx_rollback"
+	OperationConnect     Operation = "connect"
+	OperationLogin       Operation = "login"
+)
+
+var operations = []Operation{
+	OperationQuery, OperationPrepare,
+	OperationCursorOpen, OperationCursorFetch, OperationCursorClose,
+	OperationTxBegin, OperationTxCommit, OperationTxRollback,
+	OperationConnect, OperationLogin,
+}
+
+// latencyBounds are the upper bounds of the buckets of latency
+// histograms.
+var latencyBounds = []time.Duration{
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// LatencyBounds returns the upper bounds of the buckets of latency
+// histograms.
+func LatencyBounds() []time.Duration {
+	return append([]time.Duration(nil), latencyBounds...)
+}
+
+// Histogram is a latency histogram with the buckets defined by
+// LatencyBounds.
+type Histogram struct {
+	// Buckets are the number of observations less than or equal to
+	// the bound with the same index in LatencyBounds. The last bucket
+	// counts observations above the last bound.
+	Buckets []uint64
+	// Count is the number of observations and Sum their total
+	// duration.
+	Count uint64
+	Sum   time.Duration
+}
+
+// Stats are counters of the communication with the server.
+type Stats struct {
+	PacketsSent     uint64
+	PacketsReceived uint64
+	BytesSent       uint64
+	BytesReceived   uint64
+
+	// RoundTrips is the number of requests sent to the server whose
+	// response was awaited.
+	RoundTrips uint64
+
+	// Prepares is the number of prepared statements and StmtCacheHits
+	// the number of executions that reused a statement prepared
+	// before, see WithStmtCacheSize.
+	Prepares      uint64
+	StmtCacheHits uint64
+
+	// CursorFetches is the number of fetches from cursors.
+	CursorFetches uint64
+	// CursorRowsBuffered is the number of rows fetched from cursors and
+	// CursorRowsConsumed the number of rows read from CursorRows.
+	CursorRowsBuffered uint64
+	CursorRowsConsumed uint64
+
+	// Latencies are histograms of the latencies per operation.
+	Latencies map[Operation]Histogram
+}
+
+// histogram is a concurrently updated Histogram.
+type histogram struct {
+	buckets []atomic.Uint64
+	count   atomic.Uint64
+	sum     atomic.Int64
+}
+
+func newHistogram() *histogram {
+	return &histogram{buckets: make([]atomic.Uint64, len(latencyBounds)+1)}
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for i < len(latencyBounds) && d > latencyBounds[i] {
+		i++
+	}
+
+	h.buckets[i].Add(1)
+	h.count.Add(1)
+	h.sum.Add(int64(d))
+}
+
+func (h *histogram) snapshot() Histogram {
+	snapshot := Histogram{
+		Buckets: make([]uint64, len(h.buckets)),
+		Count:   h.count.Load(),
+		Sum:     time.Duration(h.sum.Load()),
+	}
+
+	for i := range h.buckets {
+		snapshot.Buckets[i] = h.buckets[i].Load()
+	}
+
+	return snapshot
+}
+
+// statsCollector collects the Stats of a connection, every update is
+// applied to the parent as well.
+type statsCollector struct {
+	parent *statsCollector
+
+	packetsSent, packetsReceived atomic.Uint64
+	bytesSent, bytesReceived     atomic.Uint64
+	roundTrips                   atomic.Uint64
+	prepares                     atomic.Uint64
+	stmtCacheHits                atomic.Uint64
+	cursorFetches                atomic.Uint64
+	cursorRowsBuffered           atomic.Uint64
+	cursorRowsConsumed           atomic.Uint64
+
+	latencies map[Operation]*histogram
+}
+
+// driverStats aggregates the stats of the connections of all drivers.
+var driverStats = newStatsCollector(nil)
+
+func newStatsCollector(parent *statsCollector) *statsCollector {
+	stats := &statsCollector{
+		parent:    parent,
+		latencies: make(map[Operation]*histogram, len(operations)),
+	}
+
+	for _, op := range operations {
+		stats.latencies[op] = newHistogram()
+	}
+
+	return stats
+}
+
+// add adds n to the counter selected by field on the collector and its
+// parents.
+func (stats *statsCollector) add(field func(*statsCollector) *atomic.Uint64, n uint64) {
+	for s := stats; s != nil; s = s.parent {
+		field(s).Add(n)
+	}
+}
+
+// observe records the latency of an operation on the collector and its
+// parents.
+func (stats *statsCollector) observe(op Operation, d time.Duration) {
+	for s := stats; s != nil; s = s.parent {
+		s.latencies[op].observe(d)
+	}
+}
+
+func (stats *statsCollector) snapshot() Stats {
+	snapshot := Stats{
+		PacketsSent:        stats.packetsSent.Load(),
+		PacketsReceived:    stats.packetsReceived.Load(),
+		BytesSent:          stats.bytesSent.Load(),
+		BytesReceived:      stats.bytesReceived.Load(),
+		RoundTrips:         stats.roundTrips.Load(),
+		Prepares:           stats.prepares.Load(),
+		StmtCacheHits:      stats.stmtCacheHits.Load(),
+		CursorFetches:      stats.cursorFetches.Load(),
+		CursorRowsBuffered: stats.cursorRowsBuffered.Load(),
+		CursorRowsConsumed: stats.cursorRowsConsumed.Load(),
+		Latencies:          make(map[Operation]Histogram, len(stats.latencies)),
+	}
+
+	for op, h := range stats.latencies {
+		snapshot.Latencies[op] = h.snapshot()
+	}
+
+	return snapshot
+}
+
+// Stats returns the counters of the connection.
+func (c *Conn) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// DriverStats returns the counters aggregated over all connections of
+// all drivers, see Driver.Stats for the counters of a single driver.
+func DriverStats() Stats {
+	return driverStats.snapshot()
+}
+
+// PublishExpvar publishes DriverStats as expvar variable with the passed
+// name, e.g. "go-ase".
+//
+// Like expvar.Publish it panics if the name is already in use.
+func PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return DriverStats()
+	}))
+}
+
+// statsTracer records the latencies and counters reported through the
+// Tracer interface and passes the events to the next Tracer.
+type statsTracer struct {
+	stats *statsCollector
+	next  Tracer
+}
+
+// QueryStart implements the Tracer interface.
+func (tracer statsTracer) QueryStart(ctx context.Context, event QueryStartEvent) context.Context {
+	return tracer.next.QueryStart(ctx, event)
+}
+
+// QueryEnd implements the Tracer interface.
+func (tracer statsTracer) QueryEnd(ctx context.Context, event QueryEndEvent) {
+	tracer.stats.observe(OperationQuery, event.Duration)
+	tracer.next.QueryEnd(ctx, event)
+}
+
+// Prepare implements the Tracer interface.
+func (tracer statsTracer) Prepare(ctx context.Context, event PrepareEvent) {
+	tracer.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.prepares }, 1)
+	tracer.stats.observe(OperationPrepare, event.Duration)
+	tracer.next.Prepare(ctx, event)
+}
+
+// CursorOpen implements the Tracer interface.
+func (tracer statsTracer) CursorOpen(ctx context.Context, event CursorEvent) {
+	tracer.stats.observe(OperationCursorOpen, event.Duration)
+	tracer.next.CursorOpen(ctx, event)
+}
+
+// CursorFetch implements the Tracer interface.
+func (tracer statsTracer) CursorFetch(ctx context.Context, event CursorFetchEvent) {
+	tracer.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.cursorFetches }, 1)
+	tracer.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.cursorRowsBuffered }, uint64(event.Rows))
+	tracer.stats.observe(OperationCursorFetch, event.Duration)
+	tracer.next.CursorFetch(ctx, event)
+}
+
+// CursorClose implements the Tracer interface.
+func (tracer statsTracer) CursorClose(ctx context.Context, event CursorEvent) {
+	tracer.stats.observe(OperationCursorClose, event.Duration)
+	tracer.next.CursorClose(ctx, event)
+}
+
+// TxBegin implements the Tracer interface.
+func (tracer statsTracer) TxBegin(ctx context.Context, event TxEvent) {
+	tracer.stats.observe(OperationTxBegin, event.Duration)
+	tracer.next.TxBegin(ctx, event)
+}
+
+// TxCommit implements the Tracer interface.
+func (tracer statsTracer) TxCommit(ctx context.Context, event TxEvent) {
+	tracer.stats.observe(OperationTxCommit, event.Duration)
+	tracer.next.TxCommit(ctx, event)
+}
+
+// TxRollback implements the Tracer interface.
+func (tracer statsTracer) TxRollback(ctx context.Context, event TxEvent) {
+	tracer.stats.observe(OperationTxRollback, event.Duration)
+	tracer.next.TxRollback(ctx, event)
+}
+
+// Connect implements the Tracer interface.
+func (tracer statsTracer) Connect(ctx context.Context, event ConnectEvent) {
+	tracer.stats.observe(OperationConnect, event.Duration)
+	tracer.next.Connect(ctx, event)
+}
+
+// Login implements the Tracer interface.
+func (tracer statsTracer) Login(ctx context.Context, event LoginEvent) {
+	tracer.stats.observe(OperationLogin, event.Duration)
+	tracer.next.Login(ctx, event)
+}
+
+// countingConn counts the TDS packets and bytes sent and received
+// through a connection.
+type countingConn struct {
+	net.Conn
+	stats          *statsCollector
+	sent, received packetCounter
+}
+
+// Read implements the net.Conn interface.
+func (conn *countingConn) Read(b []byte) (int, error) {
+	n, err := conn.Conn.Read(b)
+	conn.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.bytesReceived }, uint64(n))
+	conn.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.packetsReceived }, conn.received.count(b[:n]))
+	return n, err
+}
+
+// Write implements the net.Conn interface.
+func (conn *countingConn) Write(b []byte) (int, error) {
+	n, err := conn.Conn.Write(b)
+	conn.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.bytesSent }, uint64(n))
+	conn.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.packetsSent }, conn.sent.count(b[:n]))
+	return n, err
+}
+
+// tdsPacketHeaderSize is the size of the header of a TDS packet, which
+// contains the length of the packet including the header at offset 2.
+const tdsPacketHeaderSize = 8
+
+// packetCounter counts the TDS packets in a stream of bytes.
+type packetCounter struct {
+	header    [tdsPacketHeaderSize]byte
+	headerLen int
+	// remaining is the number of bytes of the current packet body
+	// that have not been seen yet.
+	remaining int
+}
+
+// count returns the number of packets whose header is completed by b.
+func (counter *packetCounter) count(b []byte) uint64 {
+	var packets uint64
+
+	for len(b) > 0 {
+		if counter.remaining > 0 {
+			n := counter.remaining
+			if n > len(b) {
+				n = len(b)
+			}
+			counter.remaining -= n
+			b = b[n:]
+			continue
+		}
+
+		n := copy(counter.header[counter.headerLen:], b)
+		counter.headerLen += n
+		b = b[n:]
+
+		if counter.headerLen == tdsPacketHeaderSize {
+			counter.remaining = int(binary.BigEndian.Uint16(counter.header[2:4])) - tdsPacketHeaderSize
+			counter.headerLen = 0
+			packets++
+		}
+	}
+
+	return packets
+}
+
+// roundTrip counts a request whose response is awaited.
+func (stats *statsCollector) roundTrip() {
+	stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.roundTrips }, 1)
+}
+
+// cursorRowConsumed counts a row read from CursorRows.
+func (stats *statsCollector) cursorRowConsumed() {
+	stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.cursorRowsConsumed }, 1)
+}
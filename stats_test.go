@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	server := newTransactionServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.NoQueryCursor = true
+		return nil
+	})
+
+	before := DriverStats()
+
+	tx, err := conn.BeginTx(context.Background(), DefaultTxOptions())
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	stats := conn.Stats()
+
+	if stats.RoundTrips != 3 {
+		t.Errorf("expected 3 round trips, got %d", stats.RoundTrips)
+	}
+
+	// The login exchanges packets as well.
+	if stats.PacketsSent <= 3 || stats.PacketsReceived <= 3 {
+		t.Errorf("expected more than 3 packets sent and received, got %d and %d", stats.PacketsSent, stats.PacketsReceived)
+	}
+
+	if stats.BytesSent < stats.PacketsSent*tdsPacketHeaderSize || stats.BytesReceived < stats.PacketsReceived*tdsPacketHeaderSize {
+		t.Errorf("expected at least the packet headers to be counted, got %d and %d bytes", stats.BytesSent, stats.BytesReceived)
+	}
+
+	for op, count := range map[Operation]uint64{
+		OperationConnect:    1,
+		OperationLogin:      1,
+		OperationTxBegin:    1,
+		OperationQuery:      1,
+		OperationTxCommit:   1,
+		OperationTxRollback: 0,
+	} {
+		if h := stats.Latencies[op]; h.Count != count {
+			t.Errorf("expected %d observations of %s, got %d", count, op, h.Count)
+		}
+	}
+
+	after := DriverStats()
+	if after.RoundTrips-before.RoundTrips < 3 {
+		t.Errorf("expected driver stats to include round trips of the connection")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram()
+	h.observe(50 * time.Microsecond)
+	h.observe(time.Millisecond)
+	h.observe(time.Minute)
+
+	snapshot := h.snapshot()
+
+	if snapshot.Count != 3 || snapshot.Sum != time.Minute+time.Millisecond+50*time.Microsecond {
+		t.Errorf("unexpected count %d and sum %s", snapshot.Count, snapshot.Sum)
+	}
+
+	for i, expected := range map[int]uint64{0: 1, 2: 1, len(latencyBounds): 1} {
+		if snapshot.Buckets[i] != expected {
+			t.Errorf("expected %d observations in bucket %d, got %d", expected, i, snapshot.Buckets[i])
+		}
+	}
+}
+
+func TestLatencyBounds(t *testing.T) {
+	bounds := LatencyBounds()
+	bounds[0] = time.Hour
+	_ = append(bounds, time.Hour)
+
+	// Modifying the returned bounds does not affect the histograms.
+	if LatencyBounds()[0] != 100*time.Microsecond {
+		t.Errorf("expected first bound 100µs, got %s", LatencyBounds()[0])
+	}
+
+	newHistogram().observe(2 * time.Hour)
+}
+
+func TestPacketCounter(t *testing.T) {
+	// Two packets of 10 and 8 bytes.
+	stream := []byte{
+		0x0f, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x00, 0x00, 0xaa, 0xbb,
+		0x0f, 0x01, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	for _, split := range []int{1, 3, 9, 10, 11, len(stream)} {
+		counter := &packetCounter{}
+
+		var packets uint64
+		for b := stream; len(b) > 0; {
+			n := split
+			if n > len(b) {
+				n = len(b)
+			}
+			packets += counter.count(b[:n])
+			b = b[n:]
+		}
+
+		if packets != 2 {
+			t.Errorf("expected 2 packets with chunks of %d bytes, got %d", split, packets)
+		}
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	PublishExpvar("go-ase-test")
+
+	v := expvar.Get("go-ase-test")
+	if v == nil {
+		t.Fatalf("expected published variable")
+	}
+
+	var stats Stats
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatalf("error decoding published stats: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultStmtCacheSize is the number of statements each connection
+// keeps prepared unless set with WithStmtCacheSize.
+const DefaultStmtCacheSize = 64
+
+// WithStmtCacheSize sets the number of statements each connection keeps
+// prepared.
+//
+// Statements with arguments executed through Conn.GenericExec, e.g.
+// by sql.DB.ExecContext and sql.DB.QueryContext, are prepared on the
+// server. The prepared statements are cached per connection and
+// database and reused when the same query is executed again, the least
+// recently used statement is deallocated when the cache is full.
+func WithStmtCacheSize(n int) ConnectorOption {
+	return func(c *Connector) error {
+		if n < 1 {
+			return fmt.Errorf("go-ase: statement cache size must be positive, got %d", n)
+		}
+
+		c.StmtCacheSize = n
+		return nil
+	}
+}
+
+// WithoutStmtCache disables the statement cache, see WithStmtCacheSize.
+// Statements with arguments are prepared on every execution instead.
+func WithoutStmtCache() ConnectorOption {
+	return func(c *Connector) error {
+		c.disableStmtCache = true
+		return nil
+	}
+}
+
+// stmtCacheSize returns the number of statements cached per
+// connection, which is zero if the cache is disabled.
+func (c *Connector) stmtCacheSize() int {
+	if c.disableStmtCache {
+		return 0
+	}
+	if c.StmtCacheSize > 0 {
+		return c.StmtCacheSize
+	}
+	return DefaultStmtCacheSize
+}
+
+// stmtCacheKey identifies a cached statement. Statements are bound to
+// the database they are prepared in.
+type stmtCacheKey struct {
+	database, query string
+}
+
+// stmtCache caches prepared statements of a connection, evicting the
+// least recently used statement when it is full.
+type stmtCache struct {
+	// lock guards the cache and is held while a missing statement is
+	// prepared, so that concurrent executions of a query prepare it
+	// once.
+	lock  sync.Mutex
+	size  int
+	stmts map[stmtCacheKey]*list.Element
+	// order holds the statements, the most recently used first.
+	order *list.List
+}
+
+type stmtCacheEntry struct {
+	key  stmtCacheKey
+	stmt *Stmt
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:  size,
+		stmts: make(map[stmtCacheKey]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+// cachedStmt returns the prepared statement of query, which is prepared
+// and cached if it is not cached yet.
+//
+// Statements are not cached if the cache of the connection is disabled,
+// in which case the statement is prepared on every call.
+func (c *Conn) cachedStmt(ctx context.Context, query string) (*Stmt, error) {
+	if c.stmtCache == nil {
+		return c.NewStmt(ctx, "", query, true)
+	}
+
+	cache := c.stmtCache
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	key := stmtCacheKey{database: c.env.Session().Database, query: query}
+	if elem, ok := cache.stmts[key]; ok {
+		cache.order.MoveToFront(elem)
+		c.stats.add(func(s *statsCollector) *atomic.Uint64 { return &s.stmtCacheHits }, 1)
+		return elem.Value.(*stmtCacheEntry).stmt, nil
+	}
+
+	stmt, err := c.NewStmt(ctx, "", query, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.stmts[key] = cache.order.PushFront(&stmtCacheEntry{key: key, stmt: stmt})
+
+	if cache.order.Len() > cache.size {
+		evicted := cache.order.Remove(cache.order.Back()).(*stmtCacheEntry)
+		delete(cache.stmts, evicted.key)
+
+		// The statement was prepared successfully, hence the
+		// connection is not occupied by open rows.
+		err := evicted.stmt.close(ctx)
+		c.logger.logResult(ctx, LogLevelDebug, "statement cache eviction", err,
+			"query", evicted.key.query)
+	}
+
+	return stmt, nil
+}
+
+// clear removes all statements from the cache without deallocating
+// them, e.g. when the connection is closed.
+func (cache *stmtCache) clear() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	for _, elem := range cache.stmts {
+		entry := elem.Value.(*stmtCacheEntry)
+		if entry.stmt.stmtId != nil {
+			stmtIdPool.Release(entry.stmt.stmtId)
+		}
+	}
+
+	cache.stmts = make(map[stmtCacheKey]*list.Element, cache.size)
+	cache.order.Init()
+}
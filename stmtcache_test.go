@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+func TestStmtCache(t *testing.T) {
+	queries := []string{"delete from t where a = ?", "delete from u where a = ?"}
+
+	cases := map[string]struct {
+		opts []ConnectorOption
+		// execs are the indices of the executed queries.
+		execs    []int
+		prepares uint64
+		hits     uint64
+	}{
+		"hits": {
+			execs:    []int{0, 0, 1, 0, 1},
+			prepares: 2,
+			hits:     3,
+		},
+		"eviction": {
+			opts:     []ConnectorOption{WithStmtCacheSize(1)},
+			execs:    []int{0, 0, 1, 0},
+			prepares: 3,
+			hits:     1,
+		},
+		"disabled": {
+			opts:     []ConnectorOption{WithoutStmtCache()},
+			execs:    []int{0, 0, 1},
+			prepares: 3,
+			hits:     0,
+		},
+	}
+
+	for name, cas := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(t)
+			for _, query := range queries {
+				server.Params(query, asetypes.INT8)
+				server.Handle(query, asetest.Result{RowsAffected: 1})
+			}
+
+			conn := server.connect(cas.opts...)
+
+			for _, i := range cas.execs {
+				result, err := conn.ExecContext(context.Background(), queries[i], []driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+				if err != nil {
+					t.Fatalf("error executing %q: %v", queries[i], err)
+				}
+
+				if affected, _ := result.RowsAffected(); affected != 1 {
+					t.Errorf("expected 1 affected row, got %d", affected)
+				}
+			}
+
+			stats := conn.Stats()
+			if stats.Prepares != cas.prepares {
+				t.Errorf("expected %d prepares, got %d", cas.prepares, stats.Prepares)
+			}
+			if stats.StmtCacheHits != cas.hits {
+				t.Errorf("expected %d statement cache hits, got %d", cas.hits, stats.StmtCacheHits)
+			}
+		})
+	}
+}
+
+func TestWithStmtCacheSize(t *testing.T) {
+	if err := WithStmtCacheSize(0)(&Connector{}); err == nil {
+		t.Errorf("expected error for statement cache size 0")
+	}
+
+	connector := &Connector{}
+	if size := connector.stmtCacheSize(); size != DefaultStmtCacheSize {
+		t.Errorf("expected default size %d, got %d", DefaultStmtCacheSize, size)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// tdsChannel is the part of tds.Channel the exchanges of a connection
+// send requests and read responses through.
+type tdsChannel interface {
+	QueuePackage(ctx context.Context, pkg tds.Package) error
+	SendRemainingPackets(ctx context.Context) error
+	SendPackage(ctx context.Context, pkg tds.Package) error
+	NextPackage(ctx context.Context, wait bool) (tds.Package, error)
+	SetLastPkgRx(pkg tds.Package)
+}
+
+var _ tdsChannel = (*tds.Channel)(nil)
+
+// doneMode is the interpretation of the DonePackages of a response by
+// its consumer.
+type doneMode int
+
+const (
+	// doneResults ends the response with TDS_DONE_FINAL or a bare
+	// TDS_DONE_COUNT and fails it with TDS_DONE_ERROR, see
+	// handleDonePackage.
+	doneResults doneMode = iota
+	// doneResultSets skips to the next result set after TDS_DONE_MORE
+	// and ends the response otherwise.
+	doneResultSets
+	// doneCursor continues after TDS_DONE_COUNT, which reports the
+	// rows of a fetch or precedes the final DonePackage of a cursor
+	// command, and handles other DonePackages like doneResults.
+	doneCursor
+)
+
+// resultStream is the table of handlers a consumer reads the packages
+// of a response with.
+//
+// The consumers only handle the packages carrying their results, the
+// interpretation of TDS_DONE, TDS_RETURNSTATUS, TDS_CURINFO and
+// TDS_ORDERBY packages is shared. Packages without handler are logged
+// and fail the response.
+type resultStream struct {
+	// operation describes the consumer in log entries.
+	operation string
+
+	// done is the interpretation of DonePackages.
+	done doneMode
+	// count is called with the count of DonePackages with
+	// TDS_DONE_COUNT if set.
+	count func(count int32)
+
+	rowFmt func(pkg *tds.RowFmtPackage) (bool, error)
+	row    func(pkg *tds.RowPackage) (bool, error)
+	// curInfo is called with the CurInfoPackages with the command
+	// TDS_CUR_CMD_INFORM, other commands fail the response.
+	curInfo func(pkg *tds.CurInfoPackage) (bool, error)
+}
+
+// readStream reads the packages of the current response with the
+// handlers of stream, with the semantics of nextPackageUntil.
+func (c *Conn) readStream(ctx context.Context, wait bool, stream resultStream) (tds.Package, error) {
+	return c.nextPackageUntil(ctx, wait, func(pkg tds.Package) (bool, error) {
+		return c.handleStreamPackage(ctx, stream, pkg)
+	})
+}
+
+// handleStreamPackage handles pkg with the handlers of stream.
+func (c *Conn) handleStreamPackage(ctx context.Context, stream resultStream, pkg tds.Package) (bool, error) {
+	switch typed := pkg.(type) {
+	case *tds.RowFmtPackage:
+		if stream.rowFmt != nil {
+			return stream.rowFmt(typed)
+		}
+	case *tds.RowPackage:
+		if stream.row != nil {
+			return stream.row(typed)
+		}
+	case *tds.CurInfoPackage:
+		if stream.curInfo != nil {
+			if typed.Command != tds.TDS_CUR_CMD_INFORM {
+				return true, fmt.Errorf("go-ase: received %T with command %s instead of TDS_CUR_CMD_INFORM",
+					typed, typed.Command)
+			}
+			return stream.curInfo(typed)
+		}
+	case *tds.OrderByPackage, *tds.OrderBy2Package:
+		return false, nil
+	case *tds.ReturnStatusPackage:
+		if typed.ReturnValue != 0 {
+			return true, fmt.Errorf("go-ase: query failed with return status %d", typed.ReturnValue)
+		}
+		return false, nil
+	case *tds.DonePackage:
+		if stream.count != nil && typed.Status&tds.TDS_DONE_COUNT == tds.TDS_DONE_COUNT {
+			stream.count(typed.Count)
+		}
+		return c.handleStreamDone(stream.done, typed)
+	}
+
+	c.logUnhandledPackage(ctx, stream.operation, pkg)
+	return true, fmt.Errorf("go-ase: unhandled package type %T: %v", pkg, pkg)
+}
+
+// handleStreamDone handles pkg with the interpretation mode.
+func (c *Conn) handleStreamDone(mode doneMode, pkg *tds.DonePackage) (bool, error) {
+	switch mode {
+	case doneResultSets:
+		c.trackTransaction(pkg)
+		if pkg.Status&tds.TDS_DONE_MORE == tds.TDS_DONE_MORE {
+			return false, nil
+		}
+		return true, fmt.Errorf("go-ase: no next result set: %w", io.EOF)
+	case doneCursor:
+		if pkg.Status&tds.TDS_DONE_COUNT == tds.TDS_DONE_COUNT && pkg.Status&tds.TDS_DONE_ERROR != tds.TDS_DONE_ERROR {
+			c.trackTransaction(pkg)
+			return false, nil
+		}
+	}
+
+	ok, err := c.handleDonePackage(pkg)
+	if err != nil {
+		return true, fmt.Errorf("go-ase: %w", err)
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,501 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-dblib/asetypes"
+	"github.com/SAP/go-dblib/tds"
+)
+
+// errBlocked is returned by scriptedChannel when a package is awaited
+// after the end of a response, which blocks a tds.Channel forever.
+var errBlocked = errors.New("waiting for a package after the end of the response")
+
+// scriptedChannel is a tdsChannel returning a scripted response to each
+// sent message.
+type scriptedChannel struct {
+	responses [][]tds.Package
+	// current are the unread packages of the current response.
+	current []tds.Package
+
+	// blocked is set if a package was awaited after the end of
+	// a response.
+	blocked bool
+	// leftover is set if a message was sent before the previous
+	// response was read completely.
+	leftover bool
+}
+
+var _ tdsChannel = (*scriptedChannel)(nil)
+
+func newScriptedChannel(responses ...[]tds.Package) *scriptedChannel {
+	return &scriptedChannel{responses: responses}
+}
+
+// respond makes the next scripted response the current response.
+//
+// Like tds.Channel a TDS_DONE_FINAL is appended to responses that do not
+// end with one.
+func (ch *scriptedChannel) respond() error {
+	if len(ch.current) > 0 {
+		ch.leftover = true
+	}
+
+	if len(ch.responses) == 0 {
+		return errors.New("no scripted response left")
+	}
+
+	response := ch.responses[0]
+	ch.responses = ch.responses[1:]
+
+	ch.current = append([]tds.Package{}, response...)
+	if len(response) == 0 || !isDoneFinal(response[len(response)-1]) {
+		ch.current = append(ch.current, &tds.DonePackage{Status: tds.TDS_DONE_FINAL})
+	}
+
+	return nil
+}
+
+// consumed reports whether the current response has been read
+// completely.
+func (ch *scriptedChannel) consumed() bool {
+	return len(ch.current) == 0
+}
+
+func (ch *scriptedChannel) QueuePackage(ctx context.Context, pkg tds.Package) error {
+	return nil
+}
+
+func (ch *scriptedChannel) SendRemainingPackets(ctx context.Context) error {
+	return ch.respond()
+}
+
+func (ch *scriptedChannel) SendPackage(ctx context.Context, pkg tds.Package) error {
+	return ch.respond()
+}
+
+func (ch *scriptedChannel) NextPackage(ctx context.Context, wait bool) (tds.Package, error) {
+	if len(ch.current) == 0 {
+		if !wait {
+			return nil, tds.ErrNoPackageReady
+		}
+		ch.blocked = true
+		return nil, errBlocked
+	}
+
+	pkg := ch.current[0]
+	ch.current = ch.current[1:]
+	return pkg, nil
+}
+
+func (ch *scriptedChannel) SetLastPkgRx(pkg tds.Package) {}
+
+// streamRowFmt is the format of the rows of scripted responses.
+func streamRowFmt(t testing.TB) *tds.RowFmtPackage {
+	fieldFmt, err := tds.LookupFieldFmt(asetypes.INT4)
+	if err != nil {
+		t.Fatalf("error looking up field format: %v", err)
+	}
+
+	return &tds.RowFmtPackage{Fmts: []tds.FieldFmt{fieldFmt}}
+}
+
+// streamRow returns a row of scripted responses with value.
+func streamRow(t testing.TB, value int32) *tds.RowPackage {
+	_, fieldData, err := tds.LookupFieldFmtData(asetypes.INT4)
+	if err != nil {
+		t.Fatalf("error looking up field data: %v", err)
+	}
+	fieldData.SetValue(value)
+
+	return &tds.RowPackage{ParamsPackage: tds.ParamsPackage{DataFields: []tds.FieldData{fieldData}}}
+}
+
+// decodeResponses decodes fuzz input to responses.
+//
+// Each byte selects a package, whose fields are read from the following
+// bytes. A TDS_DONE_FINAL ends a response.
+func decodeResponses(t testing.TB, data []byte) [][]tds.Package {
+	next := func() byte {
+		if len(data) == 0 {
+			return 0
+		}
+		b := data[0]
+		data = data[1:]
+		return b
+	}
+
+	var responses [][]tds.Package
+	var response []tds.Package
+	for len(data) > 0 {
+		var pkg tds.Package
+		switch next() % 9 {
+		case 0:
+			pkg = &tds.EEDPackage{MsgNumber: uint32(next()), Class: next() % 20, Msg: "message"}
+		case 1:
+			pkg = streamRowFmt(t)
+		case 2:
+			pkg = streamRow(t, int32(next()))
+		case 3:
+			pkg = &tds.OrderByPackage{}
+		case 4:
+			pkg = &tds.OrderBy2Package{}
+		case 5:
+			status := tds.DoneState(next()) & (tds.TDS_DONE_MORE | tds.TDS_DONE_ERROR | tds.TDS_DONE_INXACT |
+				tds.TDS_DONE_PROC | tds.TDS_DONE_COUNT | tds.TDS_DONE_ATTN)
+			pkg = &tds.DonePackage{Status: status, Count: int32(next())}
+		case 6:
+			pkg = &tds.ReturnStatusPackage{ReturnValue: int32(int8(next()))}
+		case 7:
+			pkg = &tds.CurInfoPackage{
+				Command: tds.CursorCommand(next()%4 + 1),
+				Status:  tds.CursorIStatus(next()),
+			}
+		case 8:
+			pkg = &tds.LoginAckPackage{}
+		}
+
+		response = append(response, pkg)
+		if isDoneFinal(pkg) {
+			responses = append(responses, response)
+			response = nil
+		}
+	}
+
+	if len(response) > 0 || len(responses) == 0 {
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+// newStreamConn returns a connection exchanging messages through ch.
+func newStreamConn(ch *scriptedChannel) *Conn {
+	return &Conn{
+		channel:        ch,
+		lock:           &sync.Mutex{},
+		serverInfoLock: &sync.Mutex{},
+		messages:       &messages{},
+		env:            &connEnv{},
+		stats:          newStatsCollector(nil),
+		tracer:         NoopTracer{},
+	}
+}
+
+// checkStream fails the test if the consumer of the current response
+// blocked or left the connection unusable.
+func checkStream(t *testing.T, conn *Conn, ch *scriptedChannel) {
+	t.Helper()
+
+	if ch.blocked {
+		t.Fatalf("consumer waited for a package after the end of the response")
+	}
+
+	if !ch.consumed() {
+		t.Fatalf("response was not read completely, unread packages: %v", ch.current)
+	}
+
+	if conn.openRows != nil {
+		t.Fatalf("rows still occupy the connection")
+	}
+
+	if !conn.lock.TryLock() {
+		t.Fatalf("connection was not released")
+	}
+	conn.lock.Unlock()
+
+	// The connection must be reusable for another statement.
+	ch.responses = [][]tds.Package{{
+		streamRowFmt(t),
+		streamRow(t, 1),
+		&tds.DonePackage{Status: tds.TDS_DONE_COUNT, Count: 1},
+	}}
+	if ch.leftover {
+		t.Fatalf("message was sent before the previous response was read")
+	}
+
+	rows, _, err := conn.DirectExec(context.Background(), "select 1")
+	if err != nil {
+		t.Fatalf("error executing statement after the response: %v", err)
+	}
+
+	dst := make([]driver.Value, 1)
+	if err := rows.Next(dst); err != nil {
+		t.Fatalf("error reading row after the response: %v", err)
+	}
+
+	if dst[0] != int32(1) {
+		t.Fatalf("expected 1 after the response, got %v", dst[0])
+	}
+
+	if err := rows.Next(dst); err != io.EOF {
+		t.Fatalf("expected io.EOF after the row, got %v", err)
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Fatalf("error closing rows: %v", err)
+	}
+
+	if !ch.consumed() || ch.blocked || ch.leftover {
+		t.Fatalf("statement after the response was not read correctly")
+	}
+}
+
+// readRows reads and closes rows like database/sql.
+//
+// io.EOF must only be returned at the end of the response or of
+// a result set.
+func readRows(t *testing.T, rows *Rows, ch *scriptedChannel) {
+	t.Helper()
+
+	// Each iteration reads at least one package.
+	for i := len(ch.current); i >= 0; i-- {
+		dst := make([]driver.Value, len(rows.Columns()))
+
+		err := rows.Next(dst)
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			if err != io.EOF {
+				t.Fatalf("database/sql expects an unwrapped io.EOF, got %v", err)
+			}
+
+			if !rows.hasNextResultSet && !rows.isClosed() && !ch.consumed() {
+				t.Fatalf("io.EOF before the end of the response, unread packages: %v", ch.current)
+			}
+
+			if !rows.HasNextResultSet() {
+				break
+			}
+
+			if err := rows.NextResultSet(); err != nil {
+				break
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	if err := rows.Close(); err != nil && !errors.Is(err, errBlocked) {
+		t.Logf("error closing rows: %v", err)
+	}
+}
+
+// streamSeeds are responses seeding the fuzz targets.
+var streamSeeds = [][]byte{
+	// rows
+	{1, 2, 1, 2, 2, 5, 0x10, 2},
+	// multiple result sets
+	{1, 2, 1, 5, 0x11, 1, 1, 2, 2, 5, 0x10, 1},
+	// error with message
+	{0, 1, 11, 5, 0x02, 0},
+	// procedure with return status
+	{6, 0, 5, 0x08, 0, 6, 1, 5, 0x20, 0},
+	// cursor fetch with rows
+	{7, 2, 0x02, 1, 2, 1, 5, 0x10, 1},
+	// exhausted cursor
+	{7, 2, 0x04, 7, 2, 0x40, 5, 0x10, 0},
+	// unhandled package
+	{8, 3, 4},
+	// responses of multiple messages
+	{2, 1, 5, 0, 0, 2, 2, 5, 0x10, 1, 5, 0, 0},
+}
+
+func addStreamSeeds(f *testing.F) {
+	for _, seed := range streamSeeds {
+		f.Add(seed)
+	}
+}
+
+func FuzzHandleDonePackage(f *testing.F) {
+	for _, status := range []tds.DoneState{
+		tds.TDS_DONE_FINAL, tds.TDS_DONE_MORE, tds.TDS_DONE_ERROR,
+		tds.TDS_DONE_INXACT, tds.TDS_DONE_PROC, tds.TDS_DONE_COUNT,
+		tds.TDS_DONE_COUNT | tds.TDS_DONE_MORE,
+	} {
+		f.Add(uint16(status), int32(1))
+	}
+
+	f.Fuzz(func(t *testing.T, status uint16, count int32) {
+		conn := newStreamConn(newScriptedChannel())
+		pkg := &tds.DonePackage{Status: tds.DoneState(status), Count: count}
+
+		ok, err := conn.handleDonePackage(pkg)
+
+		if errors.Is(err, io.EOF) && !ok {
+			t.Errorf("io.EOF without ending the response for %s", pkg.Status)
+		}
+
+		if pkg.Status == tds.TDS_DONE_FINAL && (!ok || !errors.Is(err, io.EOF)) {
+			t.Errorf("expected the end of the response for %s, got %t, %v", pkg.Status, ok, err)
+		}
+
+		if pkg.Status&tds.TDS_DONE_ERROR == tds.TDS_DONE_ERROR && (err == nil || errors.Is(err, io.EOF)) {
+			t.Errorf("expected an error for %s, got %v", pkg.Status, err)
+		}
+	})
+}
+
+func FuzzGenericResults(f *testing.F) {
+	addStreamSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ch := newScriptedChannel(decodeResponses(t, data)...)
+		conn := newStreamConn(ch)
+
+		rows, _, err := conn.language(context.Background(), "statement")
+		if err != nil {
+			if errors.Is(err, errBlocked) {
+				t.Fatalf("consumer waited for a package after the end of the response")
+			}
+			if conn.openRows != nil {
+				t.Fatalf("rows occupy the connection after an error")
+			}
+		} else {
+			readRows(t, rows.(*Rows), ch)
+		}
+
+		checkStream(t, conn, ch)
+	})
+}
+
+// openStreamRows returns rows reading the first response of ch.
+func openStreamRows(t *testing.T, conn *Conn, ch *scriptedChannel) *Rows {
+	if err := ch.respond(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := conn.NewRows()
+	rows.RowFmt = streamRowFmt(t)
+	conn.openRows = rows
+
+	return rows
+}
+
+func FuzzRowsNext(f *testing.F) {
+	addStreamSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ch := newScriptedChannel(decodeResponses(t, data)...)
+		conn := newStreamConn(ch)
+
+		readRows(t, openStreamRows(t, conn, ch), ch)
+
+		checkStream(t, conn, ch)
+	})
+}
+
+func FuzzRowsNextResultSet(f *testing.F) {
+	addStreamSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ch := newScriptedChannel(decodeResponses(t, data)...)
+		conn := newStreamConn(ch)
+		rows := openStreamRows(t, conn, ch)
+
+		// Each result set starts with at least one package.
+		for i := len(ch.current); i >= 0; i-- {
+			if err := rows.NextResultSet(); err != nil {
+				break
+			}
+		}
+
+		if err := rows.Close(); err != nil && !errors.Is(err, errBlocked) {
+			t.Logf("error closing rows: %v", err)
+		}
+
+		checkStream(t, conn, ch)
+	})
+}
+
+// newStreamCursor returns an open cursor of conn.
+func newStreamCursor(t *testing.T, conn *Conn) *Cursor {
+	cursor := &Cursor{
+		conn:     conn,
+		poolName: cursorPool.Acquire(),
+		cursorID: 1,
+		rowFmt:   streamRowFmt(t),
+	}
+	t.Cleanup(func() { cursorPool.Release(cursor.poolName) })
+
+	return cursor
+}
+
+func FuzzCursorRowsFetch(f *testing.F) {
+	addStreamSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		responses := decodeResponses(t, data)
+		ch := newScriptedChannel(responses...)
+		conn := newStreamConn(ch)
+
+		rows, err := newStreamCursor(t, conn).NewCursorRows()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Each fetch reads one response and each row is read once,
+		// after the last response sending the fetch fails.
+		reads := len(responses) + 1
+		for _, response := range responses {
+			reads += len(response)
+		}
+
+		var rowErr error
+		for i := 0; i < reads && rowErr == nil; i++ {
+			dst := make([]driver.Value, 1)
+			rowErr = rows.Next(dst)
+
+			if rowErr == io.EOF && !ch.consumed() {
+				t.Fatalf("io.EOF before the end of the response, unread packages: %v", ch.current)
+			}
+
+			if rowErr != nil && len(rows.rows) > 0 {
+				t.Fatalf("error with unread rows: %v", rowErr)
+			}
+
+			if errors.Is(rowErr, errBlocked) {
+				t.Fatalf("consumer waited for a package after the end of the response")
+			}
+		}
+
+		if rowErr == nil {
+			t.Fatalf("fetched more rows than scripted")
+		}
+
+		checkStream(t, conn, ch)
+	})
+}
+
+func FuzzCursorCloseReadResponse(f *testing.F) {
+	addStreamSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ch := newScriptedChannel(decodeResponses(t, data)...)
+		conn := newStreamConn(ch)
+		cursor := newStreamCursor(t, conn)
+
+		if err := ch.respond(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := cursor.closeReadResponse(context.Background()); errors.Is(err, errBlocked) {
+			t.Fatalf("consumer waited for a package after the end of the response")
+		}
+
+		checkStream(t, conn, ch)
+	})
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2020 SAP SE
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asetime is used to convert golang data type duration to
+// defined ASE data type duration and the other way around.
+//
+// The types, functions, and variables/constants defined in this package
+// are mainly used in asetype/goValue.go as well as in asetype/bytes.go.
+package asetime
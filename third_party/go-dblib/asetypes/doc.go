@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2020 SAP SE
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// The package asetypes contains code to link, convert, and work with
+// golang and their respective ASE data types.
+package asetypes
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2020 SAP SE
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dblib contains shared code for the go and cgo implementations
+// of go-ase as well as general purpose libraries.
+//
+// As a general rule of thumb code than can be contained in a single file
+// can be placed in this package. If the code requires multiple files
+// and/or code generation a new package should be created.
+
+package dblib
@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// The package dsn is a toolbox of functions to fill and format structs
+// from varying sources. It acts entirely upon struct metadata.
+package dsn
@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "log"
+
+func ExampleDoMain() {
+	if err := DoMain(); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	//
+	// b=false database="dbname" host="host" i=5 password="pass" port="2222" s="a string" username="user"
+}
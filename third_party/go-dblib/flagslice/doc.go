@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2020 SAP SE
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package flagslice defines slice types to be used as custom flag types for the flag library.
+
+Slice types as custom flag types allow a flag to be passed multiple
+times, e.g. to accept multiple options.
+
+	var fOpts = &flagslice.FlagStringSlice{}
+
+	flag.Var(fOpts, "o", "Options in key/value form, can be passed multiple times")
+	flag.Parse()
+
+	for i, fOpt := range fOpts.Slice() {
+		log.Printf("Passed value no. %d: %s", i, fOpt)
+	}
+
+*/
+package flagslice
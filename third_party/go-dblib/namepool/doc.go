@@ -0,0 +1,9 @@
+// SPDX-FileCopyrightText: 2020 SAP SE
+// SPDX-FileCopyrightText: 2021 SAP SE
+// SPDX-FileCopyrightText: 2022 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// The package namepool is used to provide a name pool that contains
+// names that are safe to use by multiple goroutines.
+package namepool
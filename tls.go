@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tlsConfig returns the TLS configuration described by the info.
+func (info *Info) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         info.Host,
+		InsecureSkipVerify: info.TLSSkipValidation,
+	}
+
+	if info.TLSHostname != "" {
+		// Trim CN= prefix for compatibility with cgo-ase
+		tlsConfig.ServerName = strings.TrimPrefix(info.TLSHostname, "CN=")
+	}
+
+	if info.TLSCAFile != "" {
+		bs, err := os.ReadFile(info.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("go-ase: error reading CA file '%s': %w", info.TLSCAFile, err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(bs) {
+			return nil, fmt.Errorf("go-ase: could not parse any valid CA certificate from file '%s'", info.TLSCAFile)
+		}
+	}
+
+	if info.TLSClientCertFile != "" {
+		// The key may be stored alongside the certificate.
+		keyFile := info.TLSClientKeyFile
+		if keyFile == "" {
+			keyFile = info.TLSClientCertFile
+		}
+
+		cert, err := tls.LoadX509KeyPair(info.TLSClientCertFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("go-ase: error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else if info.TLSClientKeyFile != "" {
+		return nil, errors.New("go-ase: tls-client-key-file is set without tls-client-cert-file")
+	}
+
+	var err error
+	if tlsConfig.MinVersion, err = parseTLSVersion(info.TLSMinVersion); err != nil {
+		return nil, err
+	}
+
+	if tlsConfig.MaxVersion, err = parseTLSVersion(info.TLSMaxVersion); err != nil {
+		return nil, err
+	}
+
+	if tlsConfig.CipherSuites, err = parseCipherSuites(info.TLSCipherSuites); err != nil {
+		return nil, err
+	}
+
+	pins, err := parsePublicKeyPins(info.TLSPinnedPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pins) > 0 {
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyPublicKeyPins(state, pins)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseTLSVersion returns the TLS version for a version string like
+// "1.2" or "TLS1.2". An empty string returns 0, which leaves the choice
+// to crypto/tls.
+func parseTLSVersion(s string) (uint16, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	version, ok := tlsVersions[strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "TLS")]
+	if !ok {
+		return 0, fmt.Errorf("go-ase: unknown TLS version '%s'", s)
+	}
+
+	return version, nil
+}
+
+// parseCipherSuites returns the IDs of the comma-separated cipher suite
+// names as listed by tls.CipherSuites and tls.InsecureCipherSuites.
+func parseCipherSuites(s string) ([]uint16, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	known := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := []uint16{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("go-ase: unknown TLS cipher suite '%s'", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// parsePublicKeyPins parses a comma-separated list of base64 encoded
+// SHA-256 hashes of DER encoded SubjectPublicKeyInfos, optionally
+// prefixed with "sha256/".
+func parsePublicKeyPins(s string) ([][]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	pins := [][]byte{}
+	for _, pin := range strings.Split(s, ",") {
+		pin = strings.TrimPrefix(strings.TrimSpace(pin), "sha256/")
+		if pin == "" {
+			continue
+		}
+
+		hash, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil {
+			return nil, fmt.Errorf("go-ase: error decoding pinned public key '%s': %w", pin, err)
+		}
+
+		if len(hash) != sha256.Size {
+			return nil, fmt.Errorf("go-ase: pinned public key '%s' is not a SHA-256 hash", pin)
+		}
+
+		pins = append(pins, hash)
+	}
+
+	return pins, nil
+}
+
+// verifyPublicKeyPins returns an error if the public key of none of the
+// certificates the server is authenticated by matches one of the pins.
+//
+// If the certificate chain was verified any certificate of the verified
+// chains may match, e.g. the certificate of an intermediate CA.
+// Otherwise the chain presented by the server is not authenticated and
+// only the leaf certificate, whose key the server proved to own, may
+// match.
+func verifyPublicKeyPins(state tls.ConnectionState, pins [][]byte) error {
+	var certs []*x509.Certificate
+	if len(state.VerifiedChains) > 0 {
+		for _, chain := range state.VerifiedChains {
+			certs = append(certs, chain...)
+		}
+	} else if len(state.PeerCertificates) > 0 {
+		certs = state.PeerCertificates[:1]
+	}
+
+	for _, cert := range certs {
+		hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range pins {
+			if bytes.Equal(hash[:], pin) {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("go-ase: no certificate presented by the server matches a pinned public key")
+}
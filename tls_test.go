@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	cases := map[string]struct {
+		version uint16
+		err     bool
+	}{
+		"":       {0, false},
+		"1.2":    {tls.VersionTLS12, false},
+		"TLS1.3": {tls.VersionTLS13, false},
+		"tls1.0": {tls.VersionTLS10, false},
+		"1.4":    {0, true},
+	}
+
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			version, err := parseTLSVersion(input)
+			if (err != nil) != expected.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != expected.version {
+				t.Errorf("expected version %x, got %x", expected.version, version)
+			}
+		})
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	ids, err := parseCipherSuites("TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, TLS_AES_256_GCM_SHA384")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256 || ids[1] != tls.TLS_AES_256_GCM_SHA384 {
+		t.Errorf("unexpected cipher suites: %v", ids)
+	}
+
+	if _, err := parseCipherSuites("TLS_UNKNOWN"); err == nil {
+		t.Errorf("expected error for unknown cipher suite")
+	}
+}
+
+func TestVerifyPublicKeyPins(t *testing.T) {
+	cert := newTestCertificate(t)
+	other := newTestCertificate(t)
+
+	pins, err := parsePublicKeyPins("sha256/" + testPin(cert))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		state tls.ConnectionState
+		err   bool
+	}{
+		"leaf": {
+			state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		},
+		"unverified chain": {
+			state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{other, cert}},
+			err:   true,
+		},
+		"verified chain": {
+			state: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{other},
+				VerifiedChains:   [][]*x509.Certificate{{other, cert}},
+			},
+		},
+		"presented but not verified": {
+			state: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{other, cert},
+				VerifiedChains:   [][]*x509.Certificate{{other}},
+			},
+			err: true,
+		},
+		"no certificate": {
+			err: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := verifyPublicKeyPins(c.state, pins); (err != nil) != c.err {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	if _, err := parsePublicKeyPins("dGVzdA=="); err == nil {
+		t.Errorf("expected error for pin that is not a SHA-256 hash")
+	}
+}
+
+func TestTLSConfigPinnedPublicKeys(t *testing.T) {
+	ca, caKey := newTestKeyPair(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	leaf, leafKey := newTestKeyPair(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	other := newTestCertificate(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0o600); err != nil {
+		t.Fatalf("error writing CA file: %v", err)
+	}
+
+	cases := map[string]struct {
+		skipValidation bool
+		pin            *x509.Certificate
+		presented      []*x509.Certificate
+		err            bool
+	}{
+		"skip validation leaf": {
+			skipValidation: true,
+			pin:            leaf,
+			presented:      []*x509.Certificate{leaf},
+		},
+		// Without validation anyone can present the pinned
+		// certificate next to their own leaf.
+		"skip validation pinned certificate next to leaf": {
+			skipValidation: true,
+			pin:            other,
+			presented:      []*x509.Certificate{leaf, other},
+			err:            true,
+		},
+		"verified CA": {
+			pin:       ca,
+			presented: []*x509.Certificate{leaf},
+		},
+		"pinned certificate not in verified chain": {
+			pin:       other,
+			presented: []*x509.Certificate{leaf, other},
+			err:       true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			info, err := NewInfo()
+			if err != nil {
+				t.Fatalf("error creating info: %v", err)
+			}
+			info.Host = "localhost"
+			info.TLSCAFile = caFile
+			info.TLSSkipValidation = c.skipValidation
+			info.TLSPinnedPublicKeys = testPin(c.pin)
+
+			config, err := info.tlsConfig()
+			if err != nil {
+				t.Fatalf("error creating TLS config: %v", err)
+			}
+
+			err = testHandshake(config, c.presented, leafKey)
+			if (err != nil) != c.err || (err != nil && !strings.Contains(err.Error(), "pinned public key")) {
+				t.Errorf("unexpected error of handshake: %v", err)
+			}
+		})
+	}
+}
+
+// testHandshake performs a TLS handshake with a server presenting
+// chain and returns the error of the client.
+func testHandshake(config *tls.Config, chain []*x509.Certificate, key *ecdsa.PrivateKey) error {
+	serverConn, clientConn := net.Pipe()
+
+	cert := tls.Certificate{PrivateKey: key}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer serverConn.Close()
+		tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{cert}}).Handshake()
+	}()
+
+	err := tls.Client(clientConn, config).Handshake()
+	clientConn.Close()
+	<-done
+
+	return err
+}
+
+// testPin returns the base64 encoded pin of the public key of cert.
+func testPin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	cert, _ := newTestKeyPair(t, &x509.Certificate{SerialNumber: big.NewInt(1)}, nil, nil)
+	return cert
+}
+
+// newTestKeyPair creates a certificate from template signed by parent.
+// The certificate is self-signed if parent is nil.
+func newTestKeyPair(t *testing.T, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("error creating certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %v", err)
+	}
+
+	return cert, key
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+// Tracer receives events at the boundaries of database calls, e.g. to
+// create spans or record timings.
+//
+// The context passed to the methods is the context of the call, which
+// allows to relate events to spans of the application. Events ending an
+// operation carry its duration and error.
+//
+// Methods are called on the goroutine executing the call and must not
+// block. Embed NoopTracer to implement a subset of the methods.
+type Tracer interface {
+	// QueryStart is called before a statement is executed. The
+	// returned context is passed to QueryEnd and to the Prepare and
+	// CursorOpen events of the statement, e.g. to carry a span.
+	QueryStart(ctx context.Context, event QueryStartEvent) context.Context
+	// QueryEnd is called after a statement has been executed. For
+	// queries it is called once the rows are available to the caller.
+	QueryEnd(ctx context.Context, event QueryEndEvent)
+
+	// Prepare is called after a statement has been prepared.
+	Prepare(ctx context.Context, event PrepareEvent)
+
+	// CursorOpen is called after a cursor has been declared and
+	// opened.
+	CursorOpen(ctx context.Context, event CursorEvent)
+	// CursorFetch is called after rows have been fetched from
+	// a cursor.
+	CursorFetch(ctx context.Context, event CursorFetchEvent)
+	// CursorClose is called after a cursor has been closed.
+	CursorClose(ctx context.Context, event CursorEvent)
+
+	// TxBegin, TxCommit and TxRollback are called after a transaction
+	// has begun, been committed or rolled back. Commit and rollback
+	// receive the context passed to BeginTx.
+	TxBegin(ctx context.Context, event TxEvent)
+	TxCommit(ctx context.Context, event TxEvent)
+	TxRollback(ctx context.Context, event TxEvent)
+
+	// Connect is called after the network connection to the server
+	// has been established.
+	Connect(ctx context.Context, event ConnectEvent)
+	// Login is called after the login negotiation.
+	Login(ctx context.Context, event LoginEvent)
+}
+
+// QueryStartEvent is passed to Tracer.QueryStart.
+type QueryStartEvent struct {
+	Query string
+	// Args is the number of arguments.
+	Args int
+}
+
+// QueryEndEvent is passed to Tracer.QueryEnd.
+type QueryEndEvent struct {
+	Query string
+	Args  int
+	// RowsAffected is the number of rows affected by a statement,
+	// -1 for queries.
+	RowsAffected int64
+	Err          error
+	Duration     time.Duration
+}
+
+// PrepareEvent is passed to Tracer.Prepare.
+type PrepareEvent struct {
+	Query    string
+	Err      error
+	Duration time.Duration
+}
+
+// CursorEvent is passed to Tracer.CursorOpen and Tracer.CursorClose.
+type CursorEvent struct {
+	// Name is the name of the cursor.
+	Name string
+	// Query is the query of the cursor, only set for CursorOpen.
+	Query    string
+	Args     int
+	Err      error
+	Duration time.Duration
+}
+
+// CursorFetchEvent is passed to Tracer.CursorFetch.
+type CursorFetchEvent struct {
+	Name string
+	// Rows is the number of fetched rows.
+	Rows     int
+	Err      error
+	Duration time.Duration
+}
+
+// TxEvent is passed to Tracer.TxBegin, Tracer.TxCommit and
+// Tracer.TxRollback.
+type TxEvent struct {
+	// Name is the name of the transaction, if any.
+	Name      string
+	Isolation sql.IsolationLevel
+	Err       error
+	Duration  time.Duration
+}
+
+// ConnectEvent is passed to Tracer.Connect.
+type ConnectEvent struct {
+	Network  string
+	Address  string
+	Err      error
+	Duration time.Duration
+}
+
+// LoginEvent is passed to Tracer.Login.
+type LoginEvent struct {
+	Username string
+	Err      error
+	Duration time.Duration
+}
+
+// NoopTracer implements Tracer without acting on events.
+type NoopTracer struct{}
+
+var _ Tracer = NoopTracer{}
+
+// QueryStart implements the Tracer interface.
+func (NoopTracer) QueryStart(ctx context.Context, event QueryStartEvent) context.Context {
+	return ctx
+}
+
+// QueryEnd implements the Tracer interface.
+func (NoopTracer) QueryEnd(ctx context.Context, event QueryEndEvent) {}
+
+// Prepare implements the Tracer interface.
+func (NoopTracer) Prepare(ctx context.Context, event PrepareEvent) {}
+
+// CursorOpen implements the Tracer interface.
+func (NoopTracer) CursorOpen(ctx context.Context, event CursorEvent) {}
+
+// CursorFetch implements the Tracer interface.
+func (NoopTracer) CursorFetch(ctx context.Context, event CursorFetchEvent) {}
+
+// CursorClose implements the Tracer interface.
+func (NoopTracer) CursorClose(ctx context.Context, event CursorEvent) {}
+
+// TxBegin implements the Tracer interface.
+func (NoopTracer) TxBegin(ctx context.Context, event TxEvent) {}
+
+// TxCommit implements the Tracer interface.
+func (NoopTracer) TxCommit(ctx context.Context, event TxEvent) {}
+
+// TxRollback implements the Tracer interface.
+func (NoopTracer) TxRollback(ctx context.Context, event TxEvent) {}
+
+// Connect implements the Tracer interface.
+func (NoopTracer) Connect(ctx context.Context, event ConnectEvent) {}
+
+// Login implements the Tracer interface.
+func (NoopTracer) Login(ctx context.Context, event LoginEvent) {}
+
+// WithTracer sets the Tracer of the connector.
+func WithTracer(tracer Tracer) ConnectorOption {
+	return func(c *Connector) error {
+		c.Tracer = tracer
+		return nil
+	}
+}
+
+// tracer returns the Tracer of the connector or NoopTracer.
+func (c *Connector) tracer() Tracer {
+	if c.Tracer == nil {
+		return NoopTracer{}
+	}
+	return c.Tracer
+}
+
+// traceQuery calls QueryStart and returns the context returned by
+// QueryStart and a function calling QueryEnd with the result of the
+// statement and logging it.
+//
+// The statement must be executed with the returned context, so that
+// the events of cursors and prepared statements opened by it are
+// children of the query.
+func (c *Conn) traceQuery(ctx context.Context, query string, args []driver.NamedValue) (context.Context, func(result driver.Result, err error)) {
+	start := time.Now()
+	ctx = c.tracer.QueryStart(ctx, QueryStartEvent{Query: query, Args: len(args)})
+
+	return ctx, func(result driver.Result, err error) {
+		rowsAffected := int64(-1)
+		if result != nil {
+			if n, resultErr := result.RowsAffected(); resultErr == nil {
+				rowsAffected = n
+			}
+		}
+
+		event := QueryEndEvent{
+			Query:        query,
+			Args:         len(args),
+			RowsAffected: rowsAffected,
+			Err:          err,
+			Duration:     time.Since(start),
+		}
+
+		if c.logger.enabled(LogLevelDebug) || err != nil {
+			c.logger.logResult(ctx, LogLevelDebug, "statement", err,
+				"query", query, "args", c.logger.args(args),
+				"rows_affected", rowsAffected, "duration", event.Duration)
+		}
+
+		c.tracer.QueryEnd(ctx, event)
+	}
+}
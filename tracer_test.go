@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type traceKey struct{}
+
+// recordingTracer records events as strings.
+type recordingTracer struct {
+	NoopTracer
+
+	lock   sync.Mutex
+	events []string
+}
+
+func (tracer *recordingTracer) record(format string, args ...interface{}) {
+	tracer.lock.Lock()
+	defer tracer.lock.Unlock()
+
+	tracer.events = append(tracer.events, fmt.Sprintf(format, args...))
+}
+
+func (tracer *recordingTracer) QueryStart(ctx context.Context, event QueryStartEvent) context.Context {
+	tracer.record("query start %q %d", event.Query, event.Args)
+	return context.WithValue(ctx, traceKey{}, event.Query)
+}
+
+func (tracer *recordingTracer) QueryEnd(ctx context.Context, event QueryEndEvent) {
+	tracer.record("query end %q %d %d %v span:%v", event.Query, event.Args, event.RowsAffected, event.Err, ctx.Value(traceKey{}))
+}
+
+func (tracer *recordingTracer) TxBegin(ctx context.Context, event TxEvent) {
+	tracer.record("tx begin %s %v span:%v", event.Isolation, event.Err, ctx.Value(traceKey{}))
+}
+
+func (tracer *recordingTracer) TxCommit(ctx context.Context, event TxEvent) {
+	tracer.record("tx commit %v span:%v", event.Err, ctx.Value(traceKey{}))
+}
+
+func (tracer *recordingTracer) TxRollback(ctx context.Context, event TxEvent) {
+	tracer.record("tx rollback %v span:%v", event.Err, ctx.Value(traceKey{}))
+}
+
+func (tracer *recordingTracer) Connect(ctx context.Context, event ConnectEvent) {
+	tracer.record("connect %s %s %v", event.Network, event.Address, event.Err)
+}
+
+func (tracer *recordingTracer) Login(ctx context.Context, event LoginEvent) {
+	tracer.record("login %s %v", event.Username, event.Err)
+}
+
+func (tracer *recordingTracer) Prepare(ctx context.Context, event PrepareEvent) {
+	tracer.record("prepare %q %v span:%v", event.Query, event.Err, ctx.Value(traceKey{}))
+}
+
+func (tracer *recordingTracer) CursorOpen(ctx context.Context, event CursorEvent) {
+	tracer.record("cursor open %q %v span:%v", event.Query, event.Err, ctx.Value(traceKey{}))
+}
+
+func TestTracer(t *testing.T) {
+	server := newTransactionServer(t)
+	tracer := &recordingTracer{}
+	conn := server.connect(WithTracer(tracer), func(c *Connector) error {
+		c.Info.Username = "user"
+		c.Info.NoQueryCursor = true
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "tx")
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelDefault)})
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	rows, err := conn.QueryContext(context.Background(), "select 1", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	expected := []string{
+		"connect tcp " + server.Addr() + " <nil>",
+		"login user <nil>",
+		"tx begin Default <nil> span:tx",
+		`query start "insert into t values (1)" 0`,
+		`query end "insert into t values (1)" 0 1 <nil> span:insert into t values (1)`,
+		`query start "select 1" 0`,
+		`query end "select 1" 0 -1 <nil> span:select 1`,
+		"tx commit <nil> span:tx",
+	}
+	if !reflect.DeepEqual(tracer.events, expected) {
+		t.Errorf("unexpected events:\nexpected: %q\nreceived: %q", expected, tracer.events)
+	}
+}
+
+func TestTracerQueryChildren(t *testing.T) {
+	server := newScriptedServer(t)
+	tracer := &recordingTracer{}
+	conn := server.connect(WithTracer(tracer))
+
+	query := "select * from " + scriptedTable
+	rows, err := conn.QueryContext(context.Background(), query, nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	rows.Close()
+
+	withArgs := "select * from " + scriptedTable + " where b like ?"
+	args := []driver.NamedValue{{Ordinal: 1, Value: "one"}}
+	if _, err := conn.ExecContext(context.Background(), withArgs, args); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	// The events of the cursor and the prepared statement are children
+	// of the query. The first events are the connect and login.
+	expected := []string{
+		fmt.Sprintf("query start %q 0", query),
+		fmt.Sprintf("cursor open %q <nil> span:%s", query, query),
+		fmt.Sprintf("query end %q 0 -1 <nil> span:%s", query, query),
+		fmt.Sprintf("query start %q 1", withArgs),
+		fmt.Sprintf("prepare %q <nil> span:%s", withArgs, withArgs),
+		fmt.Sprintf("query end %q 1 0 <nil> span:%s", withArgs, withArgs),
+	}
+	if events := tracer.events[2:]; !reflect.DeepEqual(events, expected) {
+		t.Errorf("unexpected events:\nexpected: %q\nreceived: %q", expected, events)
+	}
+}
@@ -10,8 +10,8 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"time"
 
-	"github.com/SAP/go-dblib"
 	"github.com/SAP/go-dblib/tds"
 )
 
@@ -33,6 +33,25 @@ func DefaultTxOptions() driver.TxOptions {
 type Transaction struct {
 	conn *Conn
 	name string
+
+	// traceCtx is the context the transaction began with, which is
+	// passed to the Tracer when the transaction ends.
+	traceCtx  context.Context
+	isolation sql.IsolationLevel
+
+	// xid identifies the transaction branch if the transaction was
+	// started with Conn.XAStart.
+	xid *XID
+
+	// prevIsolation is the isolation level of the connection before
+	// the transaction, which is restored when the transaction ends.
+	prevIsolation    sql.IsolationLevel
+	restoreIsolation bool
+
+	// chained is true if the connection is switched to chained mode for
+	// the transaction, which is switched back when the transaction
+	// ends.
+	chained bool
 }
 
 // Name returns the name of the transaction.
@@ -40,6 +59,57 @@ func (tx Transaction) Name() string {
 	return tx.name
 }
 
+// XID returns the XID of the transaction branch if the transaction was
+// started with Conn.XAStart.
+func (tx Transaction) XID() (XID, bool) {
+	if tx.xid == nil {
+		return XID{}, false
+	}
+	return *tx.xid, true
+}
+
+// InTransaction returns true if the server reported an open transaction
+// in its last response.
+//
+// In chained transaction mode a transaction is opened implicitly by the
+// first statement and InTransaction returns true until the transaction
+// is committed or rolled back.
+func (c *Conn) InTransaction() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.inTransaction
+}
+
+// Chained returns true if the connection is in chained transaction
+// mode.
+func (c *Conn) Chained() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.chained
+}
+
+// trackTransaction records the transaction state reported by pkg
+// through TDS_DONE_INXACT.
+func (c *Conn) trackTransaction(pkg *tds.DonePackage) {
+	// The packages of buffered responses have been tracked when they
+	// were buffered.
+	if c.buffer != nil {
+		return
+	}
+
+	// go-dblib inserts an empty TDS_DONE_FINAL if a response does not
+	// end with one, which does not report the transaction state.
+	if c.pendingDone && *pkg == (tds.DonePackage{Status: tds.TDS_DONE_FINAL}) {
+		c.pendingDone = false
+		return
+	}
+
+	c.inTransaction = pkg.Status&tds.TDS_DONE_INXACT == tds.TDS_DONE_INXACT
+	c.pendingDone = pkg.Status != tds.TDS_DONE_FINAL && pkg.Status&tds.TDS_DONE_MORE != tds.TDS_DONE_MORE
+}
+
 // Begin implements the driver.Conn interface.
 func (c *Conn) Begin() (driver.Tx, error) {
 	return c.BeginTx(context.Background(), DefaultTxOptions())
@@ -51,66 +121,226 @@ func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 }
 
 // NewTransaction creates a new transaction.
+//
+// If Info.Chained is set the transaction runs in chained mode.
 func (c *Conn) NewTransaction(ctx context.Context, opts driver.TxOptions, name string) (*Transaction, error) {
+	return c.newTransaction(ctx, opts, name, c.Info.Chained)
+}
+
+// newTransaction creates a new transaction, switching the connection to
+// chained mode for the transaction if chained is true.
+func (c *Conn) newTransaction(ctx context.Context, opts driver.TxOptions, name string, chained bool) (*Transaction, error) {
 	tx := &Transaction{
 		conn: c,
 		name: name,
 	}
 
-	return tx, tx.begin(ctx, opts)
+	return tx, tx.begin(ctx, opts, chained)
+}
+
+func (tx *Transaction) begin(ctx context.Context, opts driver.TxOptions, chained bool) error {
+	tx.traceCtx = ctx
+	tx.isolation = sql.IsolationLevel(opts.Isolation)
+
+	start := time.Now()
+	err := tx.beginTx(ctx, opts, chained)
+	tx.conn.tracer.TxBegin(ctx, tx.traceEvent(err, start))
+	if err == nil {
+		tx.conn.tx = tx
+	}
+	return err
+}
+
+// traceEvent returns the TxEvent of an operation on the transaction.
+func (tx *Transaction) traceEvent(err error, start time.Time) TxEvent {
+	return TxEvent{
+		Name:      tx.name,
+		Isolation: tx.isolation,
+		Err:       err,
+		Duration:  time.Since(start),
+	}
 }
 
-func (tx Transaction) begin(ctx context.Context, opts driver.TxOptions) error {
+func (tx *Transaction) beginTx(ctx context.Context, opts driver.TxOptions, chained bool) error {
 	if opts.ReadOnly {
 		return errors.New("go-ase: ASE does not support read-only transactions")
 	}
 
-	isolationLvl, err := dblib.ASEIsolationLevelFromGo(sql.IsolationLevel(opts.Isolation))
-	if err != nil {
-		return fmt.Errorf("go-ase: error mapping sql.IsolationLevel to ASE isolation level: %w", err)
+	// In chained mode the statements executed after the last commit or
+	// rollback would become part of the transaction.
+	if tx.conn.Chained() && tx.conn.InTransaction() {
+		return errors.New("go-ase: connection in chained transaction mode has an open transaction")
+	}
+
+	// The isolation level is set before the transaction begins so it
+	// applies to all statements in the transaction.
+	if level := sql.IsolationLevel(opts.Isolation); level != sql.LevelDefault {
+		if err := tx.setIsolation(ctx, level); err != nil {
+			return err
+		}
+	}
+
+	if chained && !tx.conn.Chained() {
+		if err := tx.conn.SetOption(ctx, OptionChained, true); err != nil {
+			if restoreErr := tx.resetIsolation(); restoreErr != nil {
+				return fmt.Errorf("go-ase: error enabling chained transaction mode: %w (%v)", err, restoreErr)
+			}
+			return fmt.Errorf("go-ase: error enabling chained transaction mode: %w", err)
+		}
+		tx.chained = true
 	}
 
-	if isolationLvl == dblib.ASELevelInvalid {
-		return fmt.Errorf("go-ase: sql.IsolationLevel %s has no equivalent ASE isolation level", sql.IsolationLevel(opts.Isolation))
+	// In chained mode the transaction begins implicitly with the first
+	// statement.
+	if tx.conn.Chained() {
+		return nil
 	}
 
 	if _, _, err := tx.conn.GenericExec(ctx, "begin transaction "+tx.name, nil); err != nil {
+		if restoreErr := tx.resetIsolation(); restoreErr != nil {
+			return fmt.Errorf("go-ase: error initializing transaction: %w (%v)", err, restoreErr)
+		}
 		return fmt.Errorf("go-ase: error initializing transaction: %w", err)
 	}
 
-	optIsolationPkg := &tds.OptionCmdPackage{
-		Cmd:       tds.TDS_OPT_SET,
-		Option:    tds.TDS_OPT_ISOLATION,
-		OptionArg: []byte{byte(isolationLvl)},
+	return nil
+}
+
+// setIsolation sets the isolation level of the connection and records
+// the previous isolation level to be restored by resetIsolation.
+func (tx *Transaction) setIsolation(ctx context.Context, level sql.IsolationLevel) error {
+	if _, err := aseIsolationLevel(level); err != nil {
+		return err
 	}
-	if err := tx.conn.Channel.QueuePackage(ctx, optIsolationPkg); err != nil {
-		return fmt.Errorf("go-ase: error queueing package: %w", err)
+
+	prevIsolation, err := tx.conn.GetOption(ctx, OptionIsolation)
+	if err != nil {
+		return fmt.Errorf("go-ase: error retrieving isolation level: %w", err)
+	}
+
+	if prevIsolation == level {
+		return nil
+	}
+
+	if err := tx.conn.SetOption(ctx, OptionIsolation, level); err != nil {
+		return fmt.Errorf("go-ase: error setting isolation level %s: %w", level, err)
 	}
 
+	tx.prevIsolation = prevIsolation.(sql.IsolationLevel)
+	tx.restoreIsolation = true
 	return nil
 }
 
-// NewTransaction creates a new transaction.
-func (tx Transaction) NewTransaction(ctx context.Context, opts driver.TxOptions) (*Transaction, error) {
-	newTx := &Transaction{
-		conn: tx.conn,
+// resetIsolation restores the isolation level of the connection if it
+// was changed for the transaction.
+func (tx *Transaction) resetIsolation() error {
+	if !tx.restoreIsolation {
+		return nil
+	}
+	tx.restoreIsolation = false
+
+	if err := tx.conn.SetOption(context.Background(), OptionIsolation, tx.prevIsolation); err != nil {
+		return fmt.Errorf("go-ase: error restoring isolation level %s: %w", tx.prevIsolation, err)
+	}
+
+	return nil
+}
+
+// endChained switches the connection back from chained mode when the
+// transaction ends if it was switched to chained mode for the
+// transaction.
+//
+// An error switching back is stored in err unless err already holds the
+// error of the commit or rollback.
+func (tx *Transaction) endChained(err *error) {
+	if !tx.chained {
+		return
+	}
+	tx.chained = false
+
+	restoreErr := tx.conn.SetOption(context.Background(), OptionChained, false)
+	if restoreErr == nil {
+		return
+	}
+	restoreErr = fmt.Errorf("go-ase: error disabling chained transaction mode: %w", restoreErr)
+
+	if *err != nil {
+		*err = fmt.Errorf("%w (%v)", *err, restoreErr)
+		return
 	}
+	*err = restoreErr
+}
+
+// endIsolation restores the isolation level of the connection when the
+// transaction ends, even if the commit or rollback failed - otherwise
+// the connection would be returned to the pool with the isolation
+// level of the transaction.
+//
+// An error restoring the isolation level is stored in err unless err
+// already holds the error of the commit or rollback.
+func (tx *Transaction) endIsolation(err *error) {
+	restoreErr := tx.resetIsolation()
+	if restoreErr == nil {
+		return
+	}
+
+	if *err != nil {
+		*err = fmt.Errorf("%w (%v)", *err, restoreErr)
+		return
+	}
+	*err = restoreErr
+}
 
-	return newTx, newTx.begin(ctx, opts)
+// NewTransaction creates a new transaction.
+func (tx Transaction) NewTransaction(ctx context.Context, opts driver.TxOptions) (*Transaction, error) {
+	return tx.conn.NewTransaction(ctx, opts, "")
 }
 
 // Commit implements the driver.Tx interface.
-func (tx Transaction) Commit() error {
+func (tx *Transaction) Commit() error {
+	start := time.Now()
+	err := tx.commit()
+	tx.conn.tracer.TxCommit(tx.traceCtx, tx.traceEvent(err, start))
+	return err
+}
+
+func (tx *Transaction) commit() (err error) {
+	defer tx.endIsolation(&err)
+	defer tx.endChained(&err)
+
 	if _, _, err := tx.conn.GenericExec(context.Background(), "commit "+tx.name, nil); err != nil {
 		return fmt.Errorf("go-ase: error committing transaction: %w", err)
 	}
+	tx.endBranch()
 	return nil
 }
 
 // Rollback implements the driver.Tx interface.
-func (tx Transaction) Rollback() error {
+func (tx *Transaction) Rollback() error {
+	start := time.Now()
+	err := tx.rollback()
+	tx.conn.tracer.TxRollback(tx.traceCtx, tx.traceEvent(err, start))
+	return err
+}
+
+func (tx *Transaction) rollback() (err error) {
+	defer tx.endIsolation(&err)
+	defer tx.endChained(&err)
+
 	if _, _, err := tx.conn.GenericExec(context.Background(), "rollback "+tx.name, nil); err != nil {
 		return fmt.Errorf("go-ase: error rolling back transaction: %w", err)
 	}
+	tx.endBranch()
 	return nil
 }
+
+// endBranch dissociates the transaction and its transaction branch
+// from the connection.
+func (tx *Transaction) endBranch() {
+	if tx.conn.tx == tx {
+		tx.conn.tx = nil
+	}
+	if tx.conn.xa != nil && tx.conn.xa.tx == tx {
+		tx.conn.xa = nil
+	}
+}
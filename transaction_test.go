@@ -0,0 +1,474 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+)
+
+// isolationServer is a server tracking the isolation level set
+// through option commands.
+type isolationServer struct {
+	*testServer
+	isolation byte
+	// failEnd fails commit and rollback statements.
+	failEnd bool
+}
+
+func newIsolationServer(t *testing.T) *isolationServer {
+	s := &isolationServer{testServer: newTestServer(t), isolation: 1}
+
+	s.HandleFunc("TDS_OPT_SET TDS_OPT_ISOLATION", func(req asetest.Request) []asetest.Result {
+		s.isolation = req.Args[0].([]byte)[0]
+		return nil
+	})
+
+	s.HandleFunc("", func(req asetest.Request) []asetest.Result {
+		if s.failEnd && (strings.HasPrefix(req.Query, "commit") || strings.HasPrefix(req.Query, "rollback")) {
+			return []asetest.Result{{Error: &asetest.Message{
+				Number:   3902,
+				Severity: 16,
+				Text:     "The COMMIT TRANSACTION request has no corresponding BEGIN TRANSACTION.",
+			}}}
+		}
+		return nil
+	})
+
+	return s
+}
+
+func TestTransactionIsolation(t *testing.T) {
+	cases := map[string]struct {
+		isolation sql.IsolationLevel
+		end       func(*Transaction) error
+		commands  []string
+	}{
+		"commit": {
+			isolation: sql.LevelSerializable,
+			end:       (*Transaction).Commit,
+			commands: []string{
+				"TDS_OPT_LIST TDS_OPT_ISOLATION []",
+				"TDS_OPT_SET TDS_OPT_ISOLATION [3]",
+				"begin transaction ",
+				"commit ",
+				"TDS_OPT_SET TDS_OPT_ISOLATION [1]",
+			},
+		},
+		"rollback": {
+			isolation: sql.LevelReadUncommitted,
+			end:       (*Transaction).Rollback,
+			commands: []string{
+				"TDS_OPT_LIST TDS_OPT_ISOLATION []",
+				"TDS_OPT_SET TDS_OPT_ISOLATION [0]",
+				"begin transaction ",
+				"rollback ",
+				"TDS_OPT_SET TDS_OPT_ISOLATION [1]",
+			},
+		},
+		"unchanged": {
+			isolation: sql.LevelReadCommitted,
+			end:       (*Transaction).Commit,
+			commands: []string{
+				"TDS_OPT_LIST TDS_OPT_ISOLATION []",
+				"begin transaction ",
+				"commit ",
+			},
+		},
+		"default": {
+			isolation: sql.LevelDefault,
+			end:       (*Transaction).Commit,
+			commands: []string{
+				"begin transaction ",
+				"commit ",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newIsolationServer(t)
+			conn := server.connect()
+
+			tx, err := conn.NewTransaction(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(tc.isolation)}, "")
+			if err != nil {
+				t.Fatalf("error beginning transaction: %v", err)
+			}
+
+			if err := tc.end(tx); err != nil {
+				t.Fatalf("error ending transaction: %v", err)
+			}
+
+			if commands := server.commands(); !reflect.DeepEqual(commands, tc.commands) {
+				t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", tc.commands, commands)
+			}
+
+			if server.isolation != 1 {
+				t.Errorf("expected isolation level to be restored to 1, got %d", server.isolation)
+			}
+		})
+	}
+}
+
+func TestTransactionIsolationFailedEnd(t *testing.T) {
+	cases := map[string]func(*Transaction) error{
+		"commit":   (*Transaction).Commit,
+		"rollback": (*Transaction).Rollback,
+	}
+
+	for name, end := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newIsolationServer(t)
+			server.failEnd = true
+			conn := server.connect()
+
+			tx, err := conn.NewTransaction(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSerializable)}, "")
+			if err != nil {
+				t.Fatalf("error beginning transaction: %v", err)
+			}
+
+			if err := end(tx); err == nil {
+				t.Fatalf("expected error ending transaction")
+			}
+
+			if server.isolation != 1 {
+				t.Errorf("expected isolation level to be restored to 1, got %d", server.isolation)
+			}
+
+			if commands := server.commands(); commands[len(commands)-1] != "TDS_OPT_SET TDS_OPT_ISOLATION [1]" {
+				t.Errorf("expected isolation level to be restored last, received: %q", commands)
+			}
+		})
+	}
+}
+
+func TestTransactionIsolationInvalid(t *testing.T) {
+	server := newIsolationServer(t)
+	conn := server.connect()
+
+	if _, err := conn.BeginTx(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSnapshot)}); err == nil {
+		t.Errorf("expected error for isolation level without ASE equivalent")
+	}
+
+	if commands := server.commands(); len(commands) != 0 {
+		t.Errorf("expected no commands, received: %q", commands)
+	}
+}
+
+func TestStatementIsolation(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		expected string
+	}{
+		"plain":     {"select * from t", "select * from t\nat isolation 0"},
+		"semicolon": {"select * from t; ", "select * from t\nat isolation 0"},
+		"comment":   {"select * from t -- all rows", "select * from t -- all rows\nat isolation 0"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newIsolationServer(t)
+			conn := server.connect(func(c *Connector) error {
+				c.Info.NoQueryCursor = true
+				return nil
+			})
+
+			ctx := WithStatementIsolation(context.Background(), sql.LevelReadUncommitted)
+			rows, err := conn.QueryContext(ctx, tc.query, nil)
+			if err != nil {
+				t.Fatalf("error executing query: %v", err)
+			}
+			rows.Close()
+
+			// Statements other than queries are not changed.
+			if _, err := conn.ExecContext(ctx, "use master", nil); err != nil {
+				t.Fatalf("error executing statement: %v", err)
+			}
+
+			expected := []string{tc.expected, "use master"}
+			if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+				t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+			}
+		})
+	}
+}
+
+func TestStatementIsolationConnect(t *testing.T) {
+	server := newIsolationServer(t)
+	connector := server.connector(func(c *Connector) error {
+		c.Info.Database = "master"
+		return nil
+	})
+
+	ctx := WithStatementIsolation(context.Background(), sql.LevelReadUncommitted)
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+	defer conn.Close()
+
+	expected := []string{"use master"}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+// transactionServer is a server reporting open transactions through
+// TDS_DONE_INXACT.
+type transactionServer struct {
+	*testServer
+	chained       bool
+	inTransaction bool
+}
+
+func newTransactionServer(t *testing.T) *transactionServer {
+	s := &transactionServer{testServer: newTestServer(t)}
+
+	s.HandleFunc("TDS_OPT_SET TDS_OPT_CHAINXACTS", func(req asetest.Request) []asetest.Result {
+		s.chained = req.Args[0].([]byte)[0] == 1
+		return nil
+	})
+
+	s.HandleFunc("", func(req asetest.Request) []asetest.Result {
+		switch {
+		case strings.HasPrefix(req.Query, "begin transaction"):
+			s.inTransaction = true
+		case strings.HasPrefix(req.Query, "commit"), strings.HasPrefix(req.Query, "rollback"):
+			s.inTransaction = false
+			return []asetest.Result{{}}
+		case s.chained:
+			s.inTransaction = true
+		}
+
+		return []asetest.Result{{RowsAffected: 1, InTransaction: s.inTransaction}}
+	})
+
+	return s
+}
+
+func TestTransactionUnchained(t *testing.T) {
+	server := newTransactionServer(t)
+	conn := server.connect()
+
+	if conn.Chained() {
+		t.Errorf("expected connection in unchained mode")
+	}
+
+	tx, err := conn.BeginTx(context.Background(), DefaultTxOptions())
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if !conn.InTransaction() {
+		t.Errorf("expected open transaction after begin")
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if !conn.InTransaction() {
+		t.Errorf("expected open transaction after statement")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected no open transaction after commit")
+	}
+
+	expected := []string{"begin transaction ", "insert into t values (1)", "commit "}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestTransactionChained(t *testing.T) {
+	server := newTransactionServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.Chained = true
+		return nil
+	})
+
+	// Chained mode is only enabled for transactions.
+	if conn.Chained() {
+		t.Errorf("expected connection in unchained mode")
+	}
+
+	tx, err := conn.BeginTx(context.Background(), DefaultTxOptions())
+	if err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if !conn.Chained() {
+		t.Errorf("expected connection in chained mode in transaction")
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected no open transaction before the first statement")
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if !conn.InTransaction() {
+		t.Errorf("expected implicitly opened transaction")
+	}
+
+	if _, err := conn.BeginTx(context.Background(), DefaultTxOptions()); err == nil {
+		t.Errorf("expected error beginning transaction with open transaction")
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("error rolling back transaction: %v", err)
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected no open transaction after rollback")
+	}
+
+	if conn.Chained() {
+		t.Errorf("expected connection in unchained mode after rollback")
+	}
+
+	expected := []string{
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [1]",
+		"insert into t values (1)",
+		"rollback ",
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [0]",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestResetSessionChained(t *testing.T) {
+	server := newTransactionServer(t)
+	conn := server.connect()
+
+	if err := conn.SetOption(context.Background(), OptionChained, true); err != nil {
+		t.Fatalf("error enabling chained mode: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := conn.ResetSession(context.Background()); err != nil {
+		t.Fatalf("error resetting session: %v", err)
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected no open transaction after reset")
+	}
+
+	if conn.Chained() {
+		t.Errorf("expected connection in unchained mode after reset")
+	}
+
+	// The statement was executed outside of a transaction and is
+	// committed.
+	expected := []string{
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [1]",
+		"insert into t values (1)",
+		"commit transaction",
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [0]",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestResetSessionAbandonedTransaction(t *testing.T) {
+	server := newTransactionServer(t)
+	conn := server.connect(func(c *Connector) error {
+		c.Info.Chained = true
+		return nil
+	})
+
+	if _, err := conn.BeginTx(context.Background(), DefaultTxOptions()); err != nil {
+		t.Fatalf("error beginning transaction: %v", err)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := conn.ResetSession(context.Background()); err != nil {
+		t.Fatalf("error resetting session: %v", err)
+	}
+
+	if conn.InTransaction() {
+		t.Errorf("expected no open transaction after reset")
+	}
+
+	if conn.Chained() {
+		t.Errorf("expected connection in unchained mode after reset")
+	}
+
+	expected := []string{
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [1]",
+		"insert into t values (1)",
+		"rollback ",
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [0]",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestTransactionChainedPooled(t *testing.T) {
+	server := newTransactionServer(t)
+	db := sql.OpenDB(server.connector(func(c *Connector) error {
+		c.Info.Chained = true
+		return nil
+	}))
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// Statements outside of transactions are not executed in chained
+	// mode and leave no transaction open.
+	if _, err := db.Exec("insert into t values (1)"); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error beginning transaction on pooled connection: %v", err)
+	}
+
+	if _, err := tx.Exec("insert into t values (2)"); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error committing transaction: %v", err)
+	}
+
+	if _, err := db.Exec("insert into t values (3)"); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	expected := []string{
+		"insert into t values (1)",
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [1]",
+		"insert into t values (2)",
+		"commit ",
+		"TDS_OPT_SET TDS_OPT_CHAINXACTS [0]",
+		"insert into t values (3)",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
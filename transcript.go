@@ -0,0 +1,449 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// A transcript records the TDS messages exchanged on connections as
+// JSON lines, one line per message:
+//
+//	{"conn":1,"sender":"client","type":"TDS_BUF_LANG","packets":"...","packages":["..."]}
+//
+// packets are the raw packets of the message including their headers,
+// encoded as base64. packages are the parsed packages for reading,
+// error is set if the packages could not be parsed.
+//
+// Transcripts contain the data of the sessions, e.g. user names,
+// queries, parameters and result sets. With the default password
+// encryption the password is encrypted with a key negotiated during the
+// login and cannot be recovered from a transcript.
+
+// Transcript senders.
+const (
+	senderClient = "client"
+	senderServer = "server"
+)
+
+// loginRecordSize is the size of the tokenless login record sent by
+// the client at the start of the login message.
+const loginRecordSize = 568
+
+// transcriptEntry is a message recorded in a transcript.
+type transcriptEntry struct {
+	Conn     int      `json:"conn"`
+	Sender   string   `json:"sender"`
+	Type     string   `json:"type"`
+	Packets  []byte   `json:"packets"`
+	Packages []string `json:"packages,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// Recorder writes a transcript of the connections of a connector, see
+// WithRecorder.
+type Recorder struct {
+	lock  sync.Mutex
+	enc   *json.Encoder
+	conns int
+	err   error
+}
+
+// NewRecorder returns a Recorder writing the transcript to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// WithRecorder records the TDS messages of all connections of the
+// connector with recorder.
+//
+// The recorded transcript can be served to connections with Replay.
+func WithRecorder(recorder *Recorder) ConnectorOption {
+	return func(c *Connector) error {
+		c.Recorder = recorder
+		return nil
+	}
+}
+
+// Err returns the first error that occurred while writing the
+// transcript. Errors do not affect the recorded connections.
+func (recorder *Recorder) Err() error {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	return recorder.err
+}
+
+// wrap returns conn recording its messages as a new connection.
+func (recorder *Recorder) wrap(conn net.Conn) net.Conn {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	recorder.conns++
+	return &recordingConn{
+		Conn:     conn,
+		recorder: recorder,
+		sent:     messageAssembler{conn: recorder.conns, sender: senderClient},
+		received: messageAssembler{conn: recorder.conns, sender: senderServer},
+	}
+}
+
+// record writes the entry to the transcript.
+func (recorder *Recorder) record(entry transcriptEntry) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	if recorder.err != nil {
+		return
+	}
+
+	if err := recorder.enc.Encode(entry); err != nil {
+		recorder.err = fmt.Errorf("go-ase: error writing transcript: %w", err)
+	}
+}
+
+// recordingConn records the messages sent and received through
+// a connection.
+type recordingConn struct {
+	net.Conn
+	recorder       *Recorder
+	sent, received messageAssembler
+}
+
+// Read implements the net.Conn interface.
+func (conn *recordingConn) Read(b []byte) (int, error) {
+	n, err := conn.Conn.Read(b)
+	for _, entry := range conn.received.add(b[:n]) {
+		conn.recorder.record(entry)
+	}
+	return n, err
+}
+
+// Write implements the net.Conn interface.
+func (conn *recordingConn) Write(b []byte) (int, error) {
+	n, err := conn.Conn.Write(b)
+	for _, entry := range conn.sent.add(b[:n]) {
+		conn.recorder.record(entry)
+	}
+	return n, err
+}
+
+// messageAssembler assembles the TDS messages in a stream of bytes.
+type messageAssembler struct {
+	conn   int
+	sender string
+
+	// buffer holds the bytes of the incomplete packet.
+	buffer []byte
+	// message holds the complete packets of the current message.
+	message []byte
+	// last is the last format, row or parameter package, which is
+	// required to parse rows and parameters.
+	last tds.Package
+}
+
+// add returns the entries of the messages completed by b.
+func (assembler *messageAssembler) add(b []byte) []transcriptEntry {
+	var entries []transcriptEntry
+
+	assembler.buffer = append(assembler.buffer, b...)
+	for len(assembler.buffer) >= tds.PacketHeaderSize {
+		length := int(binary.BigEndian.Uint16(assembler.buffer[2:4]))
+		if length < tds.PacketHeaderSize {
+			// Not a TDS packet, record the remaining bytes as is.
+			length = len(assembler.buffer)
+		}
+		if len(assembler.buffer) < length {
+			break
+		}
+
+		packet := assembler.buffer[:length]
+		assembler.buffer = assembler.buffer[length:]
+		assembler.message = append(assembler.message, packet...)
+
+		if tds.PacketHeaderStatus(packet[1])&tds.TDS_BUFSTAT_EOM == tds.TDS_BUFSTAT_EOM {
+			entries = append(entries, assembler.entry())
+			assembler.message = nil
+		}
+	}
+
+	if len(assembler.buffer) == 0 {
+		assembler.buffer = nil
+	}
+
+	return entries
+}
+
+// entry returns the entry of the completed message.
+func (assembler *messageAssembler) entry() transcriptEntry {
+	entry := transcriptEntry{
+		Conn:    assembler.conn,
+		Sender:  assembler.sender,
+		Type:    tds.PacketHeaderType(assembler.message[0]).String(),
+		Packets: assembler.message,
+	}
+
+	pkgs, err := assembler.parse()
+	for _, pkg := range pkgs {
+		entry.Packages = append(entry.Packages, fmt.Sprintf("%v", pkg))
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
+
+// parse parses the packages of the completed message.
+func (assembler *messageAssembler) parse() ([]tds.Package, error) {
+	var data []byte
+	for packets := assembler.message; len(packets) >= tds.PacketHeaderSize; {
+		length := int(binary.BigEndian.Uint16(packets[2:4]))
+		if length < tds.PacketHeaderSize || length > len(packets) {
+			return nil, fmt.Errorf("invalid packet length %d", length)
+		}
+		data = append(data, packets[tds.PacketHeaderSize:length]...)
+		packets = packets[length:]
+	}
+
+	if tds.PacketHeaderType(assembler.message[0]) == tds.TDS_BUF_LOGIN {
+		if len(data) < loginRecordSize {
+			return nil, fmt.Errorf("login message too short: %d bytes", len(data))
+		}
+		data = data[loginRecordSize:]
+	}
+
+	queue := tds.NewPacketQueue(func() int { return len(data) + tds.PacketHeaderSize })
+	queue.AddPacket(&tds.Packet{
+		Header: tds.PacketHeader{Status: tds.TDS_BUFSTAT_EOM},
+		Data:   data,
+	})
+
+	var pkgs []tds.Package
+	for !queue.AllPacketsConsumed() {
+		token, err := queue.Byte()
+		if err != nil {
+			return pkgs, err
+		}
+
+		pkg, err := lookupPackage(tds.Token(token))
+		if err != nil {
+			return pkgs, err
+		}
+
+		// Tokenless packages consume the remaining data.
+		if tokenless, ok := pkg.(*tds.TokenlessPackage); ok {
+			tokenless.Data.WriteByte(token)
+			return append(pkgs, tokenless), nil
+		}
+
+		if acceptor, ok := pkg.(tds.LastPkgAcceptor); ok {
+			if err := acceptor.LastPkg(assembler.last); err != nil {
+				return pkgs, err
+			}
+		}
+
+		if err := pkg.ReadFrom(queue); err != nil {
+			return pkgs, fmt.Errorf("error parsing %T: %w", pkg, err)
+		}
+		pkgs = append(pkgs, pkg)
+
+		switch pkg.(type) {
+		case *tds.RowFmtPackage, *tds.ParamFmtPackage, *tds.RowPackage, *tds.ParamsPackage:
+			assembler.last = pkg
+		}
+	}
+
+	return pkgs, nil
+}
+
+// lookupPackage returns the package for token.
+//
+// go-dblib does not look up TDS_CURCLOSE, which only clients send.
+func lookupPackage(token tds.Token) (tds.Package, error) {
+	if token == tds.TDS_CURCLOSE {
+		return &tds.CurClosePackage{}, nil
+	}
+
+	return tds.LookupPackage(token)
+}
+
+// Replay is a Dialer serving the server messages of a transcript to
+// the connections it opens, see WithRecorder.
+//
+// The n-th connection opened by Replay is served the messages of the
+// n-th recorded connection. Each recorded server message is sent after
+// the preceding client messages have been received. The received
+// messages must start with the same package types as the recorded ones,
+// their content is not compared as e.g. the login contains random data.
+type Replay struct {
+	conns map[int][]transcriptEntry
+
+	lock  sync.Mutex
+	dials int
+	errs  []error
+	wg    sync.WaitGroup
+}
+
+var _ Dialer = (*Replay)(nil)
+
+// NewReplay returns a Replay serving the transcript read from r.
+func NewReplay(r io.Reader) (*Replay, error) {
+	replay := &Replay{conns: map[int][]transcriptEntry{}}
+
+	scanner := bufio.NewScanner(r)
+	// Messages can be larger than the default token size of the
+	// scanner.
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry transcriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("go-ase: error parsing transcript line %d: %w", line, err)
+		}
+
+		if entry.Sender != senderClient && entry.Sender != senderServer {
+			return nil, fmt.Errorf("go-ase: invalid sender %q in transcript line %d", entry.Sender, line)
+		}
+
+		replay.conns[entry.Conn] = append(replay.conns[entry.Conn], entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("go-ase: error reading transcript: %w", err)
+	}
+
+	return replay, nil
+}
+
+// DialContext implements the Dialer interface.
+func (replay *Replay) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	replay.lock.Lock()
+	replay.dials++
+	id := replay.dials
+	replay.lock.Unlock()
+
+	entries, ok := replay.conns[id]
+	if !ok {
+		return nil, fmt.Errorf("go-ase: transcript does not contain connection %d", id)
+	}
+
+	client, server := net.Pipe()
+
+	replay.wg.Add(1)
+	go func() {
+		defer replay.wg.Done()
+		defer server.Close()
+
+		if err := replayConn(server, entries); err != nil {
+			replay.lock.Lock()
+			replay.errs = append(replay.errs, fmt.Errorf("go-ase: replay of connection %d: %w", id, err))
+			replay.lock.Unlock()
+		}
+	}()
+
+	return client, nil
+}
+
+// Err waits until the replayed connections are closed and returns the
+// first error that occurred while replaying them.
+func (replay *Replay) Err() error {
+	replay.wg.Wait()
+
+	replay.lock.Lock()
+	defer replay.lock.Unlock()
+
+	if len(replay.errs) > 0 {
+		return replay.errs[0]
+	}
+	return nil
+}
+
+// replayConn serves the recorded server messages on conn.
+func replayConn(conn net.Conn, entries []transcriptEntry) error {
+	for i, entry := range entries {
+		if entry.Sender == senderServer {
+			if _, err := conn.Write(entry.Packets); err != nil {
+				return fmt.Errorf("error writing message %d: %w", i+1, err)
+			}
+			continue
+		}
+
+		packets, err := readPackets(conn)
+		if err != nil {
+			return fmt.Errorf("error reading message %d: %w", i+1, err)
+		}
+
+		if received, recorded := messageKind(packets), messageKind(entry.Packets); received != recorded {
+			return fmt.Errorf("message %d is %s, recorded %s", i+1, received, recorded)
+		}
+	}
+
+	// The client closes the connection after the recorded messages.
+	if _, err := readPackets(conn); !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrClosedPipe) {
+		if err == nil {
+			return fmt.Errorf("received message after the end of the transcript")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// readPackets reads the packets of a message from conn.
+func readPackets(conn net.Conn) ([]byte, error) {
+	var packets []byte
+	for {
+		header := make([]byte, tds.PacketHeaderSize)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return nil, err
+		}
+
+		length := int(binary.BigEndian.Uint16(header[2:4]))
+		if length < tds.PacketHeaderSize {
+			return nil, fmt.Errorf("invalid packet length %d", length)
+		}
+
+		packet := append(header, make([]byte, length-tds.PacketHeaderSize)...)
+		if _, err := io.ReadFull(conn, packet[tds.PacketHeaderSize:]); err != nil {
+			return nil, err
+		}
+		packets = append(packets, packet...)
+
+		if tds.PacketHeaderStatus(header[1])&tds.TDS_BUFSTAT_EOM == tds.TDS_BUFSTAT_EOM {
+			return packets, nil
+		}
+	}
+}
+
+// messageKind describes the kind of the message in packets by the type
+// of its first packet and the token of its first package.
+//
+// The login message starts with the tokenless login record.
+func messageKind(packets []byte) string {
+	if len(packets) < tds.PacketHeaderSize {
+		return "empty message"
+	}
+
+	typ := tds.PacketHeaderType(packets[0])
+	if typ == tds.TDS_BUF_LOGIN || len(packets) == tds.PacketHeaderSize {
+		return typ.String()
+	}
+
+	return typ.String() + " " + tds.Token(packets[tds.PacketHeaderSize]).String()
+}
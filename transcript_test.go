@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// transcriptSession runs the statements of the recorded session on
+// a connection of connector and returns the received messages and
+// values.
+func transcriptSession(t *testing.T, connector *Connector) (messageRecorder, []driver.Value) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := connector.Connect(ctx)
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+	defer conn.Close()
+
+	var recorder messageRecorder
+	ctx = WithMessageHandler(ctx, recorder.handle)
+
+	if _, err := conn.(*Conn).ExecContext(ctx, "print 'a'; print 'b'", nil); err != nil {
+		t.Fatalf("error executing batch: %v", err)
+	}
+
+	rows, err := conn.(*Conn).QueryContext(ctx, "select 1", nil)
+	if err != nil {
+		t.Fatalf("error executing query: %v", err)
+	}
+	defer rows.Close()
+
+	var values []driver.Value
+	row := make([]driver.Value, 1)
+	for {
+		if err := rows.Next(row); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("error reading row: %v", err)
+		}
+		values = append(values, row[0])
+	}
+
+	return recorder, values
+}
+
+// recordTranscript returns the transcript of a session with the fake
+// server.
+func recordTranscript(t *testing.T) []byte {
+	t.Helper()
+
+	var transcript bytes.Buffer
+	recorder := NewRecorder(&transcript)
+
+	server := newPrintServer(t)
+	connector := server.connector(WithRecorder(recorder), func(c *Connector) error {
+		c.Info.NoQueryCursor = true
+		return nil
+	})
+
+	messages, values := transcriptSession(t, connector)
+	if !reflect.DeepEqual(messages, messageRecorder{"0: a", "1: b"}) {
+		t.Errorf("unexpected messages: %q", messages)
+	}
+	if !reflect.DeepEqual(values, []driver.Value{int32(1)}) {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	if err := recorder.Err(); err != nil {
+		t.Fatalf("error recording transcript: %v", err)
+	}
+
+	return transcript.Bytes()
+}
+
+// replayConnector returns a connector whose connections are served by
+// replay.
+func replayConnector(t *testing.T, replay *Replay) *Connector {
+	t.Helper()
+
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host = "replay"
+	info.Port = "4901"
+	info.NoQueryCursor = true
+
+	connector, err := NewConnectorWithOptions(context.Background(), info, WithDialer(replay), WithoutValidation())
+	if err != nil {
+		t.Fatalf("error creating connector: %v", err)
+	}
+
+	return connector
+}
+
+func TestRecorder(t *testing.T) {
+	transcript := recordTranscript(t)
+
+	var entries []transcriptEntry
+	for _, line := range bytes.Split(bytes.TrimSpace(transcript), []byte("\n")) {
+		var entry transcriptEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("error parsing transcript line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	var commands []string
+	for _, entry := range entries {
+		if entry.Conn != 1 {
+			t.Errorf("unexpected connection %d", entry.Conn)
+		}
+
+		if entry.Error != "" {
+			t.Errorf("error parsing %s message: %s", entry.Sender, entry.Error)
+		}
+
+		if entry.Sender == senderClient && messageKind(entry.Packets) == "TDS_BUF_NORMAL TDS_LANGUAGE" {
+			commands = append(commands, strings.Join(entry.Packages, "\n"))
+		}
+	}
+
+	if len(entries) < 2 || entries[0].Type != "TDS_BUF_LOGIN" || entries[1].Sender != senderServer {
+		t.Fatalf("expected transcript to start with the login, got %v", entries)
+	}
+
+	if len(commands) != 2 || !strings.Contains(commands[0], "print 'a'; print 'b'") || !strings.Contains(commands[1], "select 1") {
+		t.Errorf("unexpected language commands: %q", commands)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	replay, err := NewReplay(bytes.NewReader(recordTranscript(t)))
+	if err != nil {
+		t.Fatalf("error reading transcript: %v", err)
+	}
+
+	messages, values := transcriptSession(t, replayConnector(t, replay))
+	if !reflect.DeepEqual(messages, messageRecorder{"0: a", "1: b"}) {
+		t.Errorf("unexpected messages: %q", messages)
+	}
+	if !reflect.DeepEqual(values, []driver.Value{int32(1)}) {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	if err := replay.Err(); err != nil {
+		t.Errorf("error replaying transcript: %v", err)
+	}
+
+	// The transcript contains a single connection.
+	if _, err := replayConnector(t, replay).Connect(context.Background()); err == nil {
+		t.Errorf("expected error opening second connection")
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	replay, err := NewReplay(bytes.NewReader(recordTranscript(t)))
+	if err != nil {
+		t.Fatalf("error reading transcript: %v", err)
+	}
+
+	conn, err := replayConnector(t, replay).Connect(context.Background())
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+
+	// The transcript continues with a language command.
+	if _, err := conn.(*Conn).ExecContext(context.Background(), "delete from t where a = ?",
+		[]driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err == nil {
+		t.Errorf("expected error executing statement not in transcript")
+	}
+	conn.Close()
+
+	if err := replay.Err(); err == nil || !strings.Contains(err.Error(), "recorded TDS_BUF_NORMAL TDS_LANGUAGE") {
+		t.Errorf("expected mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/SAP/go-dblib/tds"
+)
+
+// Dialer establishes the network connection to the TDS server.
+//
+// *net.Dialer implements Dialer. Custom implementations can be used to
+// route connections through tunnels or to inject faults in tests.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// DialerFunc is an adapter to use functions as Dialer.
+type DialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// DialContext implements the Dialer interface.
+func (fn DialerFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return fn(ctx, network, address)
+}
+
+// openTDSConn opens the TDS connection to the server.
+//
+// The connection is always established by the driver and passed to
+// tds.NewConnWithConn, so that its network traffic is counted in stats
+// and recorded by the recorder of the connector.
+func (c *Connector) openTDSConn(ctx context.Context, stats *statsCollector) (*tds.Conn, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = &countingConn{Conn: conn, stats: stats}
+	if c.Recorder != nil {
+		conn = c.Recorder.wrap(conn)
+	}
+
+	// The tds.Conn is bound to the context it is created with.
+	tdsConn, err := tds.NewConnWithConn(context.Background(), conn, &c.Info.Info)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error opening connection: %w", err)
+	}
+
+	return tdsConn, nil
+}
+
+// dial establishes the connection to the server, including the TLS
+// handshake if TLS is enabled.
+func (c *Connector) dial(ctx context.Context) (net.Conn, error) {
+	info := c.Info
+
+	var dialer Dialer = &net.Dialer{}
+	if c.Dialer != nil {
+		dialer = c.Dialer
+	}
+
+	if info.Proxy != "" {
+		proxy, err := newProxyDialer(info.Proxy, dialer)
+		if err != nil {
+			return nil, err
+		}
+		dialer = proxy
+	}
+
+	conn, err := dialer.DialContext(ctx, info.Network, net.JoinHostPort(info.Host, info.Port))
+	if err != nil {
+		return nil, fmt.Errorf("error opening connection: %w", err)
+	}
+
+	if !c.tlsEnabled() {
+		return conn, nil
+	}
+
+	tlsConfig := c.TLSConfig
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = info.Host
+		}
+	} else {
+		tlsConfig, err = info.tlsConfig()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error during TLS handshake with server: %w", err)
+	}
+
+	return tlsConn, nil
+}
+
+// tlsEnabled reports whether TLS is used for the connection.
+func (c *Connector) tlsEnabled() bool {
+	// TLS is enabled on port 443 without TLSEnable being set.
+	return c.TLSConfig != nil || c.Info.TLSEnable ||
+		strings.TrimLeft(strings.TrimSpace(c.Info.Port), "0") == "443"
+}
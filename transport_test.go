@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-ase/asetest"
+)
+
+func TestConnectorDialer(t *testing.T) {
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+	info.Host = "ase.example.com"
+	info.Port = "4901"
+
+	errInjected := errors.New("injected")
+	var dialedAddress string
+
+	connector := &Connector{
+		Info: info,
+		Dialer: DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialedAddress = address
+			return nil, errInjected
+		}),
+	}
+
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, errInjected) {
+		t.Errorf("expected injected error, got: %v", err)
+	}
+
+	if dialedAddress != "ase.example.com:4901" {
+		t.Errorf("expected dialer to be called with address ase.example.com:4901, got: %q", dialedAddress)
+	}
+}
+
+func TestConnectorDialerDrop(t *testing.T) {
+	info, err := NewInfo()
+	if err != nil {
+		t.Fatalf("error creating info: %v", err)
+	}
+
+	connector := &Connector{
+		Info: info,
+		Dialer: DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			client, server := net.Pipe()
+			// Drop the connection as soon as the client sends the login.
+			go func() {
+				buf := make([]byte, 1)
+				_, _ = server.Read(buf)
+				server.Close()
+			}()
+			return client, nil
+		}),
+	}
+
+	if _, err := connector.Connect(context.Background()); err == nil {
+		t.Errorf("expected error when connection is dropped during login")
+	}
+}
+
+func TestConnectorConnectWithTimeout(t *testing.T) {
+	server := newTestServer(t)
+
+	connector := server.connector(func(c *Connector) error {
+		c.Info.ConnectTimeout = 1
+		return nil
+	})
+
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("error connecting: %v", err)
+	}
+	conn.Close()
+}
+
+// closeRecordingConn records whether the connection was closed.
+type closeRecordingConn struct {
+	net.Conn
+	closed chan struct{}
+}
+
+func (conn *closeRecordingConn) Close() error {
+	select {
+	case <-conn.closed:
+	default:
+		close(conn.closed)
+	}
+	return conn.Conn.Close()
+}
+
+func TestConnectorDialerConn(t *testing.T) {
+	server := newTestServer(t)
+	server.Handle("select 'ping'", asetest.Result{})
+
+	var dialed *closeRecordingConn
+	conn := server.connect(WithDialer(DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		dialed = &closeRecordingConn{Conn: conn, closed: make(chan struct{})}
+		return dialed, nil
+	})))
+
+	if err := conn.Ping(context.Background()); err != nil {
+		t.Fatalf("error pinging through dialed connection: %v", err)
+	}
+
+	// The dialed connection is used by the tds.Conn directly and closed
+	// with it.
+	if err := conn.Close(); err != nil {
+		t.Fatalf("error closing connection: %v", err)
+	}
+
+	select {
+	case <-dialed.closed:
+	case <-time.After(5 * time.Second):
+		t.Errorf("dialed connection was not closed with the connection")
+	}
+}
+
+func TestConnectorTLSEnabled(t *testing.T) {
+	cases := map[string]bool{
+		"443":   true,
+		"0443":  true,
+		"4430":  false,
+		"44003": false,
+		"4901":  false,
+	}
+
+	for port, expected := range cases {
+		connector := &Connector{Info: &Info{}}
+		connector.Info.Port = port
+
+		if enabled := connector.tlsEnabled(); enabled != expected {
+			t.Errorf("port %s: expected TLS enabled %t, got %t", port, expected, enabled)
+		}
+	}
+}
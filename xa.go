@@ -0,0 +1,433 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Limits of the parts of an XID as defined by the X/Open XA
+// specification.
+const (
+	xidGlobalTransactionIDMaxSize = 64
+	xidBranchQualifierMaxSize     = 64
+)
+
+// xidPrefix is the prefix of transaction names created from XIDs.
+const xidPrefix = "xa_"
+
+// xidNameMaxSize is the maximum length of transaction names in ASE.
+const xidNameMaxSize = 255
+
+// xaStatesPrepared are the states of prepared transactions in
+// master..systransactions, attached to a session or detached.
+const xaStatesPrepared = "4, 65540"
+
+// ErrXAProtocol is matched by errors.Is if an XA method was called in
+// a state of the transaction branch that does not permit it, e.g.
+// XAPrepare before XAEnd.
+var ErrXAProtocol = errors.New("go-ase: XA protocol error")
+
+// XID identifies a transaction branch of a distributed transaction as
+// defined by the X/Open XA specification.
+type XID struct {
+	FormatID            int32
+	GlobalTransactionID []byte
+	BranchQualifier     []byte
+}
+
+// Validate returns an error if the XID violates the limits of the XA
+// specification or if its transaction name, see String, exceeds the
+// 255 characters ASE permits.
+//
+// As the name hex encodes both IDs the global transaction ID and the
+// branch qualifier cannot use their maximum size of 64 bytes at the
+// same time.
+func (xid XID) Validate() error {
+	if len(xid.GlobalTransactionID) == 0 || len(xid.GlobalTransactionID) > xidGlobalTransactionIDMaxSize {
+		return fmt.Errorf("go-ase: XID global transaction ID must have between 1 and %d bytes, got %d",
+			xidGlobalTransactionIDMaxSize, len(xid.GlobalTransactionID))
+	}
+
+	if len(xid.BranchQualifier) > xidBranchQualifierMaxSize {
+		return fmt.Errorf("go-ase: XID branch qualifier must have at most %d bytes, got %d",
+			xidBranchQualifierMaxSize, len(xid.BranchQualifier))
+	}
+
+	if name := xid.String(); len(name) > xidNameMaxSize {
+		return fmt.Errorf("go-ase: XID transaction name must have at most %d characters, got %d",
+			xidNameMaxSize, len(name))
+	}
+
+	return nil
+}
+
+// String returns the name of the ASE transaction for the XID.
+//
+// The name is composed of the format ID and the hex encoded global
+// transaction ID and branch qualifier, e.g. xa_1_676c6f62616c_01.
+func (xid XID) String() string {
+	return xidPrefix + strconv.FormatInt(int64(xid.FormatID), 10) +
+		"_" + hex.EncodeToString(xid.GlobalTransactionID) +
+		"_" + hex.EncodeToString(xid.BranchQualifier)
+}
+
+// ParseXID parses an XID from the name of an ASE transaction as
+// created by XID.String.
+func ParseXID(name string) (XID, error) {
+	if !strings.HasPrefix(name, xidPrefix) {
+		return XID{}, fmt.Errorf("go-ase: transaction name %q is not an XID", name)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(name, xidPrefix), "_")
+	if len(parts) != 3 {
+		return XID{}, fmt.Errorf("go-ase: transaction name %q is not an XID", name)
+	}
+
+	formatID, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return XID{}, fmt.Errorf("go-ase: invalid format ID in XID %q: %w", name, err)
+	}
+
+	gtrid, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return XID{}, fmt.Errorf("go-ase: invalid global transaction ID in XID %q: %w", name, err)
+	}
+
+	bqual, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return XID{}, fmt.Errorf("go-ase: invalid branch qualifier in XID %q: %w", name, err)
+	}
+
+	xid := XID{
+		FormatID:            int32(formatID),
+		GlobalTransactionID: gtrid,
+		BranchQualifier:     bqual,
+	}
+
+	return xid, xid.Validate()
+}
+
+// XARecoveryHandler is called with the XIDs of the prepared
+// transaction branches in doubt after a connection has been
+// established, see WithXARecovery.
+//
+// The handler is expected to complete the branches with XACommit or
+// XARollback on the passed connection. If it returns an error the
+// connection is closed.
+type XARecoveryHandler func(ctx context.Context, conn *Conn, xids []XID) error
+
+// WithXARecovery sets a handler resolving in-doubt transaction branches
+// when the connector establishes its first connection, e.g. after
+// a transaction manager restarted following a failure.
+//
+// The branches are recovered once per connector: connections
+// established while the handler runs wait for it, if it fails the
+// recovery is repeated with the next connection.
+//
+// Experimental: the recovery of transaction branches, see XARecover,
+// has not been verified against ASE yet.
+func WithXARecovery(handler XARecoveryHandler) ConnectorOption {
+	return func(c *Connector) error {
+		c.XARecoveryHandler = handler
+		return nil
+	}
+}
+
+// xaRecovery records whether the in-doubt transaction branches of
+// a connector have been recovered.
+type xaRecovery struct {
+	lock sync.Mutex
+	done bool
+}
+
+// recoverXA passes the in-doubt transaction branches to the
+// XARecoveryHandler of the connector unless they have been recovered
+// through another connection of the connector before.
+//
+// Connectors that were not created by NewConnectorWithOptions recover
+// the branches on every connection.
+func (c *Connector) recoverXA(ctx context.Context, conn *Conn) error {
+	if c.XARecoveryHandler == nil {
+		return nil
+	}
+
+	if c.xaRecovery == nil {
+		return c.recoverXABranches(ctx, conn)
+	}
+
+	c.xaRecovery.lock.Lock()
+	defer c.xaRecovery.lock.Unlock()
+
+	if c.xaRecovery.done {
+		return nil
+	}
+
+	if err := c.recoverXABranches(ctx, conn); err != nil {
+		return err
+	}
+
+	c.xaRecovery.done = true
+	return nil
+}
+
+// recoverXABranches passes the in-doubt transaction branches to the
+// XARecoveryHandler of the connector.
+func (c *Connector) recoverXABranches(ctx context.Context, conn *Conn) error {
+	xids, err := conn.XARecover(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(xids) == 0 {
+		return nil
+	}
+
+	if err := c.XARecoveryHandler(ctx, conn, xids); err != nil {
+		return fmt.Errorf("go-ase: error recovering transaction branches: %w", err)
+	}
+
+	return nil
+}
+
+// xaState is the state of a transaction branch associated with
+// a connection.
+type xaState int
+
+const (
+	xaActive xaState = iota
+	xaEnded
+	xaPrepared
+)
+
+func (state xaState) String() string {
+	switch state {
+	case xaActive:
+		return "active"
+	case xaEnded:
+		return "ended"
+	case xaPrepared:
+		return "prepared"
+	}
+	return "unknown"
+}
+
+// xaBranch is the transaction branch associated with a connection.
+type xaBranch struct {
+	tx    *Transaction
+	state xaState
+}
+
+// XAStart begins the transaction branch identified by xid on the
+// connection.
+//
+// The branch is an ASE transaction named after the XID, which is
+// returned as a Transaction. Statements executed on the connection
+// until XAEnd is called are part of the branch.
+func (c *Conn) XAStart(ctx context.Context, xid XID) (*Transaction, error) {
+	if err := xid.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.Chained() {
+		return nil, errors.New("go-ase: transaction branches cannot be started in chained transaction mode")
+	}
+
+	if c.xa != nil {
+		return nil, fmt.Errorf("%w: connection is associated with transaction branch %s", ErrXAProtocol, c.xa.tx.name)
+	}
+
+	tx, err := c.newTransaction(ctx, DefaultTxOptions(), xid.String(), false)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error starting transaction branch %s: %w", xid, err)
+	}
+
+	tx.xid = &xid
+	c.xa = &xaBranch{tx: tx, state: xaActive}
+	return tx, nil
+}
+
+// XAEnd ends the association of the connection with the work of the
+// transaction branch identified by xid.
+//
+// The branch must be ended before it can be prepared or committed in
+// one phase.
+//
+// The end of the association is only tracked by the driver, ASE has no
+// equivalent to suspend the work of a transaction. Statements executed
+// on the connection after XAEnd would still be part of the branch,
+// hence they fail with ErrXAProtocol until the branch has been
+// completed.
+func (c *Conn) XAEnd(ctx context.Context, xid XID) error {
+	if _, err := c.xaBranch(xid, xaActive); err != nil {
+		return err
+	}
+
+	c.xa.state = xaEnded
+	return nil
+}
+
+// XAPrepare prepares the ended transaction branch identified by xid
+// for commit.
+//
+// After a successful prepare the branch survives the loss of the
+// connection and can be completed with XACommit or XARollback on any
+// connection.
+//
+// Experimental: the branch is prepared with `prepare transaction`,
+// which has not been verified against ASE yet.
+func (c *Conn) XAPrepare(ctx context.Context, xid XID) error {
+	if _, err := c.xaBranch(xid, xaEnded); err != nil {
+		return err
+	}
+
+	if _, _, err := c.GenericExec(ctx, "prepare transaction", nil); err != nil {
+		return fmt.Errorf("go-ase: error preparing transaction branch %s: %w", xid, err)
+	}
+
+	c.xa.state = xaPrepared
+	return nil
+}
+
+// XACommit commits the transaction branch identified by xid.
+//
+// If onePhase is true the ended branch is committed without being
+// prepared, otherwise the branch must be prepared.
+//
+// Prepared branches that are not associated with the connection, e.g.
+// in-doubt branches returned by XARecover after a reconnect, are
+// completed through `dbcc complete_xact`. Completing these branches is
+// experimental, see XARecover.
+func (c *Conn) XACommit(ctx context.Context, xid XID, onePhase bool) error {
+	if err := xid.Validate(); err != nil {
+		return err
+	}
+
+	if c.xa == nil || c.xa.tx.name != xid.String() {
+		if onePhase {
+			return fmt.Errorf("%w: transaction branch %s is not associated with the connection", ErrXAProtocol, xid)
+		}
+		return c.xaComplete(ctx, xid, "commit")
+	}
+
+	expected := xaPrepared
+	if onePhase {
+		expected = xaEnded
+	}
+
+	tx, err := c.xaBranch(xid, expected)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// XARollback rolls back the transaction branch identified by xid.
+//
+// Prepared branches that are not associated with the connection are
+// completed through `dbcc complete_xact`. Completing these branches is
+// experimental, see XARecover.
+func (c *Conn) XARollback(ctx context.Context, xid XID) error {
+	if err := xid.Validate(); err != nil {
+		return err
+	}
+
+	if c.xa == nil || c.xa.tx.name != xid.String() {
+		return c.xaComplete(ctx, xid, "rollback")
+	}
+
+	return c.xa.tx.Rollback()
+}
+
+// XARecover returns the XIDs of the prepared transaction branches on
+// the server, which are in doubt until they are completed with
+// XACommit or XARollback.
+//
+// Transactions in master..systransactions whose names were not created
+// from an XID are ignored.
+//
+// Experimental: the states of prepared transactions (4 and 65540) and
+// their completion through `dbcc complete_xact` have only been tested
+// against a scripted server, not against ASE.
+func (c *Conn) XARecover(ctx context.Context) ([]XID, error) {
+	query := "select xactname from master..systransactions where state in (" + xaStatesPrepared +
+		") and xactname like '" + xidPrefix + "%'"
+
+	rows, _, err := c.GenericExec(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("go-ase: error listing prepared transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var xids []XID
+	values := make([]driver.Value, 1)
+	for {
+		if err := rows.Next(values); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("go-ase: error reading prepared transactions: %w", err)
+		}
+
+		name, ok := values[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("go-ase: unexpected transaction name of type %T", values[0])
+		}
+
+		xid, err := ParseXID(strings.TrimSpace(name))
+		if err != nil {
+			continue
+		}
+		xids = append(xids, xid)
+	}
+
+	return xids, nil
+}
+
+// xaBranch returns the transaction of the branch associated with the
+// connection if it is identified by xid and in the expected state.
+func (c *Conn) xaBranch(xid XID, expected xaState) (*Transaction, error) {
+	if c.xa == nil || c.xa.tx.name != xid.String() {
+		return nil, fmt.Errorf("%w: transaction branch %s is not associated with the connection", ErrXAProtocol, xid)
+	}
+
+	if c.xa.state != expected {
+		return nil, fmt.Errorf("%w: transaction branch %s is %s, expected %s", ErrXAProtocol, xid, c.xa.state, expected)
+	}
+
+	return c.xa.tx, nil
+}
+
+// xaCheckActive returns an error if the transaction branch associated
+// with the connection has been ended, as the statements would still be
+// executed as part of the branch.
+func (c *Conn) xaCheckActive() error {
+	if c.xa != nil && c.xa.state != xaActive {
+		return fmt.Errorf("%w: statements cannot be executed while transaction branch %s is %s",
+			ErrXAProtocol, c.xa.tx.name, c.xa.state)
+	}
+
+	return nil
+}
+
+// xaComplete commits or rolls back a prepared transaction branch that
+// is not associated with the connection.
+func (c *Conn) xaComplete(ctx context.Context, xid XID, action string) error {
+	query := fmt.Sprintf("dbcc complete_xact('%s', '%s')", xid, action)
+	if _, _, err := c.GenericExec(ctx, query, nil); err != nil {
+		return fmt.Errorf("go-ase: error completing transaction branch %s with %s: %w", xid, action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-ase/asetest"
+)
+
+var testXID = XID{
+	FormatID:            1,
+	GlobalTransactionID: []byte("global"),
+	BranchQualifier:     []byte{1},
+}
+
+func TestXIDString(t *testing.T) {
+	name := testXID.String()
+	if name != "xa_1_676c6f62616c_01" {
+		t.Errorf("unexpected transaction name: %s", name)
+	}
+
+	xid, err := ParseXID(name)
+	if err != nil {
+		t.Fatalf("error parsing XID: %v", err)
+	}
+
+	if !reflect.DeepEqual(xid, testXID) {
+		t.Errorf("unexpected XID:\nexpected: %#v\nreceived: %#v", testXID, xid)
+	}
+}
+
+func TestParseXIDInvalid(t *testing.T) {
+	cases := map[string]string{
+		"no prefix":      "tran_1_00_00",
+		"missing parts":  "xa_1_00",
+		"format ID":      "xa_a_00_00",
+		"global ID":      "xa_1_zz_00",
+		"empty global":   "xa_1__00",
+		"branch":         "xa_1_00_0",
+		"branch too big": "xa_1_00_" + strings.Repeat("00", xidBranchQualifierMaxSize+1),
+		"name too long": "xa_1_" + strings.Repeat("00", xidGlobalTransactionIDMaxSize) +
+			"_" + strings.Repeat("00", xidBranchQualifierMaxSize),
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseXID(tc); err == nil {
+				t.Errorf("expected error parsing %q", tc)
+			}
+		})
+	}
+}
+
+// xaServer is a server reporting the transaction names in prepared as
+// prepared transactions.
+type xaServer struct {
+	*testServer
+	prepared []string
+}
+
+func newXAServer(t *testing.T, prepared ...string) *xaServer {
+	s := &xaServer{testServer: newTestServer(t), prepared: prepared}
+	s.HandleFunc("", s.handle)
+	return s
+}
+
+func (s *xaServer) handle(req asetest.Request) []asetest.Result {
+	if !strings.HasPrefix(req.Query, "select xactname") || len(s.prepared) == 0 {
+		return nil
+	}
+
+	result := asetest.Result{Columns: []asetest.Column{{Name: "xactname"}}}
+	for _, name := range s.prepared {
+		result.Rows = append(result.Rows, []interface{}{name})
+	}
+
+	return []asetest.Result{result}
+}
+
+func TestXATwoPhaseCommit(t *testing.T) {
+	server := newXAServer(t)
+	conn := server.connect()
+	ctx := context.Background()
+
+	tx, err := conn.XAStart(ctx, testXID)
+	if err != nil {
+		t.Fatalf("error starting transaction branch: %v", err)
+	}
+
+	if xid, ok := tx.XID(); !ok || !reflect.DeepEqual(xid, testXID) {
+		t.Errorf("unexpected XID of transaction: %v", xid)
+	}
+
+	if _, err := conn.XAStart(ctx, testXID); !errors.Is(err, ErrXAProtocol) {
+		t.Errorf("expected ErrXAProtocol starting second branch, got: %v", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "insert into t values (1)", nil); err != nil {
+		t.Fatalf("error executing statement: %v", err)
+	}
+
+	if err := conn.XAPrepare(ctx, testXID); !errors.Is(err, ErrXAProtocol) {
+		t.Errorf("expected ErrXAProtocol preparing active branch, got: %v", err)
+	}
+
+	if err := conn.XAEnd(ctx, testXID); err != nil {
+		t.Fatalf("error ending transaction branch: %v", err)
+	}
+
+	if err := conn.XAPrepare(ctx, testXID); err != nil {
+		t.Fatalf("error preparing transaction branch: %v", err)
+	}
+
+	if err := conn.XACommit(ctx, testXID, true); !errors.Is(err, ErrXAProtocol) {
+		t.Errorf("expected ErrXAProtocol committing prepared branch in one phase, got: %v", err)
+	}
+
+	if err := conn.XACommit(ctx, testXID, false); err != nil {
+		t.Fatalf("error committing transaction branch: %v", err)
+	}
+
+	expected := []string{
+		"begin transaction xa_1_676c6f62616c_01",
+		"insert into t values (1)",
+		"prepare transaction",
+		"commit xa_1_676c6f62616c_01",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+
+	if _, err := conn.XAStart(ctx, testXID); err != nil {
+		t.Errorf("error starting transaction branch after commit: %v", err)
+	}
+}
+
+func TestXAOnePhaseCommit(t *testing.T) {
+	server := newXAServer(t)
+	conn := server.connect()
+	ctx := context.Background()
+
+	if _, err := conn.XAStart(ctx, testXID); err != nil {
+		t.Fatalf("error starting transaction branch: %v", err)
+	}
+
+	if err := conn.XACommit(ctx, testXID, true); !errors.Is(err, ErrXAProtocol) {
+		t.Errorf("expected ErrXAProtocol committing active branch, got: %v", err)
+	}
+
+	if err := conn.XAEnd(ctx, testXID); err != nil {
+		t.Fatalf("error ending transaction branch: %v", err)
+	}
+
+	if err := conn.XACommit(ctx, testXID, true); err != nil {
+		t.Fatalf("error committing transaction branch: %v", err)
+	}
+
+	expected := []string{
+		"begin transaction xa_1_676c6f62616c_01",
+		"commit xa_1_676c6f62616c_01",
+	}
+	if commands := server.commands(); !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+}
+
+func TestXARecovery(t *testing.T) {
+	other := XID{FormatID: 2, GlobalTransactionID: []byte{0xff}, BranchQualifier: []byte{}}
+	server := newXAServer(t, testXID.String(), "payroll", other.String())
+
+	var recovered []XID
+	conn := server.connect(WithXARecovery(func(ctx context.Context, conn *Conn, xids []XID) error {
+		recovered = xids
+		if err := conn.XACommit(ctx, xids[0], false); err != nil {
+			return err
+		}
+		return conn.XARollback(ctx, xids[1])
+	}))
+
+	expectedXIDs := []XID{testXID, other}
+	if !reflect.DeepEqual(recovered, expectedXIDs) {
+		t.Errorf("unexpected recovered XIDs:\nexpected: %v\nreceived: %v", expectedXIDs, recovered)
+	}
+
+	commands := server.commands()
+	expected := []string{
+		"dbcc complete_xact('xa_1_676c6f62616c_01', 'commit')",
+		"dbcc complete_xact('xa_2_ff_', 'rollback')",
+	}
+	if len(commands) != 3 || !reflect.DeepEqual(commands[1:], expected) {
+		t.Errorf("unexpected commands:\nexpected: %q\nreceived: %q", expected, commands)
+	}
+
+	if err := conn.XACommit(context.Background(), testXID, true); !errors.Is(err, ErrXAProtocol) {
+		t.Errorf("expected ErrXAProtocol committing unassociated branch in one phase, got: %v", err)
+	}
+}
+
+func TestXAStateMachine(t *testing.T) {
+	ctx := context.Background()
+	other := XID{FormatID: 2, GlobalTransactionID: []byte{0xff}}
+
+	cases := map[string]func(conn *Conn) error{
+		"end twice": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			return conn.XAEnd(ctx, testXID)
+		},
+		"end other branch": func(conn *Conn) error {
+			return conn.XAEnd(ctx, other)
+		},
+		"prepare other branch": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			return conn.XAPrepare(ctx, other)
+		},
+		"prepare twice": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			if err := conn.XAPrepare(ctx, testXID); err != nil {
+				t.Fatalf("error preparing transaction branch: %v", err)
+			}
+			return conn.XAPrepare(ctx, testXID)
+		},
+		"two-phase commit of ended branch": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			return conn.XACommit(ctx, testXID, false)
+		},
+		"exec after end": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			_, err := conn.ExecContext(ctx, "insert into t values (1)", nil)
+			return err
+		},
+		"query after prepare": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			if err := conn.XAPrepare(ctx, testXID); err != nil {
+				t.Fatalf("error preparing transaction branch: %v", err)
+			}
+			_, err := conn.QueryContext(ctx, "select a from t", nil)
+			return err
+		},
+		"prepare statement after end": func(conn *Conn) error {
+			if err := conn.XAEnd(ctx, testXID); err != nil {
+				t.Fatalf("error ending transaction branch: %v", err)
+			}
+			_, err := conn.PrepareContext(ctx, "insert into t values (?)")
+			return err
+		},
+	}
+
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newXAServer(t)
+			conn := server.connect()
+
+			if _, err := conn.XAStart(ctx, testXID); err != nil {
+				t.Fatalf("error starting transaction branch: %v", err)
+			}
+
+			if err := fn(conn); !errors.Is(err, ErrXAProtocol) {
+				t.Errorf("expected ErrXAProtocol, got: %v", err)
+			}
+
+			// The branch can be rolled back in any state, which
+			// permits statements again.
+			if err := conn.XARollback(ctx, testXID); err != nil {
+				t.Fatalf("error rolling back transaction branch: %v", err)
+			}
+
+			if _, err := conn.ExecContext(ctx, "insert into t values (1)", nil); err != nil {
+				t.Errorf("error executing statement after rollback: %v", err)
+			}
+		})
+	}
+}
+
+func TestXARecoveryOnce(t *testing.T) {
+	server := newXAServer(t, testXID.String())
+
+	errInjected := errors.New("injected")
+	calls := 0
+	connector := server.connector(WithXARecovery(func(ctx context.Context, conn *Conn, xids []XID) error {
+		calls++
+		if calls == 1 {
+			return errInjected
+		}
+		return nil
+	}))
+
+	// A failed recovery is repeated with the next connection.
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, errInjected) {
+		t.Fatalf("expected injected error, got: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		conn, err := connector.Connect(context.Background())
+		if err != nil {
+			t.Fatalf("error connecting: %v", err)
+		}
+		conn.Close()
+	}
+
+	if calls != 2 {
+		t.Errorf("expected recovery handler to be called twice, got %d calls", calls)
+	}
+}